@@ -81,6 +81,7 @@ func LessOrEqualInAccuracy(a, b float64) bool {
 }
 
 // RenderByte renders a byte size to string (using %.2f), support `B` `KB` `MB` `GB` `TB`.
+// Please use ByteSize for SI units, IEC units and configurable precision.
 func RenderByte(bytes float64) string {
 	divider := float64(1024)
 
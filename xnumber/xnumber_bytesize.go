@@ -0,0 +1,216 @@
+package xnumber
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize represents a size of bytes, which can be parsed from and formatted to a human-readable string, such as "1.5GiB"
+// and "200MB". ByteSize implements encoding.TextMarshaler and encoding.TextUnmarshaler, so it can be used in config files,
+// and can also be used with `default` tag in xreflect.FillDefaultFields.
+type ByteSize int64
+
+const (
+	Byte ByteSize = 1 // 1 byte.
+
+	KB ByteSize = 1000 * Byte // SI kilobyte, 1000 bytes.
+	MB ByteSize = 1000 * KB   // SI megabyte, 1000^2 bytes.
+	GB ByteSize = 1000 * MB   // SI gigabyte, 1000^3 bytes.
+	TB ByteSize = 1000 * GB   // SI terabyte, 1000^4 bytes.
+	PB ByteSize = 1000 * TB   // SI petabyte, 1000^5 bytes.
+	EB ByteSize = 1000 * PB   // SI exabyte, 1000^6 bytes.
+
+	KiB ByteSize = 1024 * Byte // IEC kibibyte, 1024 bytes.
+	MiB ByteSize = 1024 * KiB  // IEC mebibyte, 1024^2 bytes.
+	GiB ByteSize = 1024 * MiB  // IEC gibibyte, 1024^3 bytes.
+	TiB ByteSize = 1024 * GiB  // IEC tebibyte, 1024^4 bytes.
+	PiB ByteSize = 1024 * TiB  // IEC pebibyte, 1024^5 bytes.
+	EiB ByteSize = 1024 * PiB  // IEC exbibyte, 1024^6 bytes.
+)
+
+// ByteSizeStyle represents the unit style used in ByteSize.Render.
+type ByteSizeStyle uint8
+
+const (
+	// ByteSizeIEC means using 1024 as divisor and "KiB" "MiB" ... "EiB" as unit, this is the default style.
+	ByteSizeIEC ByteSizeStyle = iota
+
+	// ByteSizeSI means using 1000 as divisor and "KB" "MB" ... "EB" as unit.
+	ByteSizeSI
+
+	// ByteSizeJEDEC means using 1024 as divisor and "KB" "MB" ... "EB" as unit, this is the style used in RenderByte.
+	ByteSizeJEDEC
+)
+
+var (
+	_siUnits  = [...]string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+	_iecUnits = [...]string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+	// _byteSizeUnits represents the units can be parsed in ParseByteSize, the keys are in lowercase.
+	_byteSizeUnits = map[string]ByteSize{
+		"": Byte, "b": Byte, "byte": Byte, "bytes": Byte,
+		"k": KB, "kb": KB, "ki": KiB, "kib": KiB,
+		"m": MB, "mb": MB, "mi": MiB, "mib": MiB,
+		"g": GB, "gb": GB, "gi": GiB, "gib": GiB,
+		"t": TB, "tb": TB, "ti": TiB, "tib": TiB,
+		"p": PB, "pb": PB, "pi": PiB, "pib": PiB,
+		"e": EB, "eb": EB, "ei": EiB, "eib": EiB,
+	}
+)
+
+var (
+	errInvalidByteSize  = errors.New("xnumber: invalid byte size")
+	errByteSizeOverflow = errors.New("xnumber: byte size overflows int64")
+)
+
+// ParseByteSize parses a human-readable string to ByteSize. Here the units are case-insensitive, "KB" "MB" ... "EB" (and
+// "K" "M" ... "E") are SI units using 1000 as divisor, "KiB" "MiB" ... "EiB" (and "Ki" "Mi" ... "Ei") are IEC units using
+// 1024 as divisor, and the number without unit (or with "B" unit) means bytes. Note that the fractional bytes will be rounded.
+//
+// Example:
+// 	ParseByteSize("1.5GiB") // => 1610612736
+// 	ParseByteSize("200 MB") // => 200000000
+// 	ParseByteSize("-1k")    // => -1000
+// 	ParseByteSize("1.5XB")  // => error
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	idx := 0
+	for idx < len(s) && (s[idx] == '.' || (s[idx] >= '0' && s[idx] <= '9')) {
+		idx++
+	}
+	number, unitName := s[:idx], strings.TrimSpace(s[idx:])
+	unit, ok := _byteSizeUnits[strings.ToLower(unitName)]
+	if number == "" || number == "." || !ok {
+		return 0, errInvalidByteSize
+	}
+
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++ // -1 << 63
+	}
+	var size uint64
+	if !strings.Contains(number, ".") {
+		u, err := strconv.ParseUint(number, 10, 64)
+		if err != nil || u > limit/uint64(unit) {
+			return 0, errByteSizeOverflow // number is validated, so err must be range error
+		}
+		size = u * uint64(unit)
+	} else {
+		f, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, errInvalidByteSize // such as "1.2.3"
+		}
+		f = math.Round(f * float64(unit))
+		if f >= float64(limit) && !(negative && f == float64(limit)) {
+			return 0, errByteSizeOverflow
+		}
+		size = uint64(f)
+	}
+	if negative {
+		return ByteSize(-size), nil // -(1 << 63) is also correct
+	}
+	return ByteSize(size), nil
+}
+
+// Render renders ByteSize to string using given ByteSizeStyle and precision, here precision means the number of digits
+// after the decimal point, and -1 means the smallest number of digits necessary to represent the value exactly. Note that
+// the value less than 1KB (or 1KiB) is always rendered as integer bytes, and the value which reaches the next unit after
+// rounding is rendered using the next unit.
+//
+// Example:
+// 	ByteSize(1536).Render(ByteSizeIEC, 2)   // => 1.50KiB
+// 	ByteSize(1536).Render(ByteSizeSI, -1)   // => 1.536KB
+// 	ByteSize(1536).Render(ByteSizeJEDEC, 1) // => 1.5KB
+func (b ByteSize) Render(style ByteSizeStyle, precision int) string {
+	divisor, units := float64(1024), _iecUnits[:]
+	switch style {
+	case ByteSizeSI:
+		divisor, units = 1000, _siUnits[:]
+	case ByteSizeJEDEC:
+		units = _siUnits[:]
+	}
+
+	if b > -ByteSize(divisor) && b < ByteSize(divisor) {
+		return strconv.FormatInt(int64(b), 10) + units[0]
+	}
+	f := math.Abs(float64(b))
+	idx := 0
+	for f >= divisor && idx < len(units)-1 {
+		f /= divisor
+		idx++
+	}
+	number := strconv.FormatFloat(f, 'f', precision, 64)
+	if r, _ := strconv.ParseFloat(number, 64); r >= divisor && idx < len(units)-1 {
+		f /= divisor // rounded up to the next unit, such as 1023.999KiB to 1.00MiB
+		idx++
+		number = strconv.FormatFloat(f, 'f', precision, 64)
+	}
+	sign := ""
+	if b < 0 {
+		sign = "-"
+	}
+	return sign + number + units[idx]
+}
+
+// String returns the human-readable string of ByteSize using ByteSizeIEC style and at most 2 digits after the decimal point.
+//
+// Example:
+// 	ByteSize(1536).String()        // => 1.5KiB
+// 	ByteSize(1 << 30).String()     // => 1GiB
+// 	ByteSize(1000 * 1000).String() // => 976.56KiB
+func (b ByteSize) String() string {
+	s := b.Render(ByteSizeIEC, 2)
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		end := idx
+		for end < len(s) && (s[end] == '.' || (s[end] >= '0' && s[end] <= '9')) {
+			end++
+		}
+		number := strings.TrimRight(strings.TrimRight(s[:end], "0"), ".")
+		s = number + s[end:]
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler, the ByteSize is marshaled to an exact string using the largest IEC or SI
+// unit which can divide it, such as "1536B", "2KiB" and "200MB", so it can be unmarshaled to the same value.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	if b != 0 {
+		for idx := len(_iecUnits) - 1; idx > 0; idx-- {
+			for _, unit := range []struct {
+				size ByteSize
+				name string
+			}{{byteSizePow(1024, idx), _iecUnits[idx]}, {byteSizePow(1000, idx), _siUnits[idx]}} {
+				if b%unit.size == 0 {
+					return []byte(strconv.FormatInt(int64(b/unit.size), 10) + unit.name), nil
+				}
+			}
+		}
+	}
+	return []byte(strconv.FormatInt(int64(b), 10) + "B"), nil
+}
+
+// byteSizePow returns base^exp in ByteSize.
+func byteSizePow(base ByteSize, exp int) ByteSize {
+	result := Byte
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, the text is parsed by ParseByteSize.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
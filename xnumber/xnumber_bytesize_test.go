@@ -0,0 +1,161 @@
+package xnumber
+
+import (
+	"encoding/json"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	for _, tc := range []struct {
+		give    string
+		want    ByteSize
+		wantErr error
+	}{
+		{"0", 0, nil},
+		{"1024", 1024, nil},
+		{"1024B", 1024, nil},
+		{" 10 bytes ", 10, nil},
+		{"1.5GiB", 1610612736, nil},
+		{"1.5gib", 1610612736, nil},
+		{"200MB", 200000000, nil},
+		{"200 mb", 200000000, nil},
+		{"1k", KB, nil},
+		{"1Ki", KiB, nil},
+		{"+2KiB", 2 * KiB, nil},
+		{"-1k", -KB, nil},
+		{".5KB", 500, nil},
+		{"1.", 1, nil},
+		{"0.0001KB", 0, nil},
+		{"1.0005KB", 1001, nil},
+		{"1TB", TB, nil},
+		{"1TiB", TiB, nil},
+		{"3PB", 3 * PB, nil},
+		{"1EB", EB, nil},
+		{"7EiB", 7 * EiB, nil},
+		{"9223372036854775807", math.MaxInt64, nil},
+		{"-9223372036854775808", math.MinInt64, nil},
+		{"-8EiB", math.MinInt64, nil},
+		{"-8.0EiB", math.MinInt64, nil},
+		{"9223372036854775808", 0, errByteSizeOverflow},
+		{"8EiB", 0, errByteSizeOverflow},
+		{"8.0EiB", 0, errByteSizeOverflow},
+		{"10EB", 0, errByteSizeOverflow},
+		{"", 0, errInvalidByteSize},
+		{"-", 0, errInvalidByteSize},
+		{".", 0, errInvalidByteSize},
+		{"KB", 0, errInvalidByteSize},
+		{"1XB", 0, errInvalidByteSize},
+		{"1.2.3KB", 0, errInvalidByteSize},
+		{"1e3", 0, errInvalidByteSize},
+		{"1 K B", 0, errInvalidByteSize},
+	} {
+		b, err := ParseByteSize(tc.give)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, b, tc.want)
+	}
+}
+
+func TestByteSizeRender(t *testing.T) {
+	for _, tc := range []struct {
+		give          ByteSize
+		giveStyle     ByteSizeStyle
+		givePrecision int
+		want          string
+	}{
+		{0, ByteSizeIEC, 2, "0B"},
+		{1023, ByteSizeIEC, 2, "1023B"},
+		{-1023, ByteSizeIEC, 2, "-1023B"},
+		{1024, ByteSizeIEC, 2, "1.00KiB"},
+		{1536, ByteSizeIEC, 2, "1.50KiB"},
+		{1536, ByteSizeIEC, -1, "1.5KiB"},
+		{1536, ByteSizeIEC, 0, "2KiB"},
+		{-1536, ByteSizeIEC, 1, "-1.5KiB"},
+		{1536, ByteSizeSI, -1, "1.536KB"},
+		{999, ByteSizeSI, 2, "999B"},
+		{1000, ByteSizeSI, 2, "1.00KB"},
+		{1536, ByteSizeJEDEC, 1, "1.5KB"},
+		{1023, ByteSizeJEDEC, 1, "1023B"},
+		{1<<20 - 1, ByteSizeIEC, 2, "1.00MiB"},
+		{-(1<<20 - 1), ByteSizeIEC, 2, "-1.00MiB"},
+		{1<<20 - 1, ByteSizeIEC, 3, "1023.999KiB"},
+		{1<<20 - 1, ByteSizeIEC, -1, "1023.9990234375KiB"},
+		{999999, ByteSizeSI, 2, "1.00MB"},
+		{999499, ByteSizeSI, 0, "999KB"},
+		{999500, ByteSizeSI, 0, "1MB"},
+		{GiB + 512*MiB, ByteSizeIEC, 3, "1.500GiB"},
+		{TB, ByteSizeSI, 0, "1TB"},
+		{5 * PiB, ByteSizeIEC, 1, "5.0PiB"},
+		{EiB, ByteSizeIEC, 2, "1.00EiB"},
+		{math.MaxInt64, ByteSizeIEC, 2, "8.00EiB"},
+		{math.MinInt64, ByteSizeIEC, 2, "-8.00EiB"},
+		{math.MaxInt64, ByteSizeSI, 2, "9.22EB"},
+		{math.MaxInt64, ByteSizeJEDEC, 2, "8.00EB"},
+	} {
+		xtesting.Equal(t, tc.give.Render(tc.giveStyle, tc.givePrecision), tc.want)
+	}
+
+	for _, tc := range []struct {
+		give ByteSize
+		want string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{-512, "-512B"},
+		{1024, "1KiB"},
+		{1536, "1.5KiB"},
+		{-1536, "-1.5KiB"},
+		{1 << 30, "1GiB"},
+		{MB, "976.56KiB"},
+		{GB, "953.67MiB"},
+		{10 * TiB, "10TiB"},
+	} {
+		xtesting.Equal(t, tc.give.String(), tc.want)
+	}
+}
+
+func TestByteSizeText(t *testing.T) {
+	for _, tc := range []struct {
+		give ByteSize
+		want string
+	}{
+		{0, "0B"},
+		{1, "1B"},
+		{1000, "1KB"},
+		{1024, "1KiB"},
+		{1536, "1536B"},
+		{2 * MiB, "2MiB"},
+		{-2 * MiB, "-2MiB"},
+		{200 * MB, "200MB"},
+		{3 * GiB, "3GiB"},
+		{EiB, "1EiB"},
+		{EB, "1EB"},
+		{math.MaxInt64, "9223372036854775807B"},
+		{math.MinInt64, "-8EiB"},
+	} {
+		bs, err := tc.give.MarshalText()
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, string(bs), tc.want)
+		var b ByteSize
+		xtesting.Nil(t, b.UnmarshalText(bs))
+		xtesting.Equal(t, b, tc.give)
+	}
+
+	var b ByteSize = 5
+	xtesting.Equal(t, b.UnmarshalText([]byte("x")), errInvalidByteSize)
+	xtesting.Equal(t, b, ByteSize(5))
+
+	type config struct {
+		Limit  ByteSize  `json:"limit"`
+		Buffer *ByteSize `json:"buffer"`
+	}
+	cfg := &config{}
+	xtesting.Nil(t, json.Unmarshal([]byte(`{"limit": "1.5GiB", "buffer": "64KiB"}`), cfg))
+	xtesting.Equal(t, cfg.Limit, GiB+512*MiB)
+	xtesting.Equal(t, *cfg.Buffer, 64*KiB)
+	bs, err := json.Marshal(cfg)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, string(bs), `{"limit":"1536MiB","buffer":"64KiB"}`)
+	xtesting.NotNil(t, json.Unmarshal([]byte(`{"limit": "1.5XB"}`), cfg))
+}
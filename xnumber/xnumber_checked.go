@@ -0,0 +1,742 @@
+package xnumber
+
+import (
+	"errors"
+)
+
+const (
+	maxUint = ^uint(0)          // 1 << 32 - 1 or 1 << 64 - 1, depends on IntSize.
+	maxInt  = int(maxUint >> 1) // 1 << 31 - 1 or 1 << 63 - 1, depends on IntSize.
+	minInt  = -maxInt - 1       // -1 << 31 or -1 << 63, depends on IntSize.
+)
+
+var (
+	errIntegerOutOfRange = errors.New("xnumber: integer value out of range")
+)
+
+// checked
+
+// AddIntChecked returns a + b and true if the result does not overflow int, otherwise returns 0 and false.
+func AddIntChecked(a, b int) (int, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// SubIntChecked returns a - b and true if the result does not overflow int, otherwise returns 0 and false.
+func SubIntChecked(a, b int) (int, bool) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// MulIntChecked returns a * b and true if the result does not overflow int, otherwise returns 0 and false.
+func MulIntChecked(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == minInt) || (b == -1 && a == minInt) {
+		return 0, false
+	}
+	return c, true
+}
+
+// DivIntChecked returns a / b and true if b is not zero and the result does not overflow int (that is the minimum int / -1),
+// otherwise returns 0 and false.
+func DivIntChecked(a, b int) (int, bool) {
+	if b == 0 || (a == minInt && b == -1) {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddInt8Checked returns a + b and true if the result does not overflow int8, otherwise returns 0 and false.
+func AddInt8Checked(a, b int8) (int8, bool) {
+	c := int64(a) + int64(b)
+	if c < int64(MinInt8) || c > int64(MaxInt8) {
+		return 0, false
+	}
+	return int8(c), true
+}
+
+// SubInt8Checked returns a - b and true if the result does not overflow int8, otherwise returns 0 and false.
+func SubInt8Checked(a, b int8) (int8, bool) {
+	c := int64(a) - int64(b)
+	if c < int64(MinInt8) || c > int64(MaxInt8) {
+		return 0, false
+	}
+	return int8(c), true
+}
+
+// MulInt8Checked returns a * b and true if the result does not overflow int8, otherwise returns 0 and false.
+func MulInt8Checked(a, b int8) (int8, bool) {
+	c := int64(a) * int64(b)
+	if c < int64(MinInt8) || c > int64(MaxInt8) {
+		return 0, false
+	}
+	return int8(c), true
+}
+
+// DivInt8Checked returns a / b and true if b is not zero and the result does not overflow int8 (that is MinInt8 / -1),
+// otherwise returns 0 and false.
+func DivInt8Checked(a, b int8) (int8, bool) {
+	if b == 0 || (a == MinInt8 && b == -1) {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddInt16Checked returns a + b and true if the result does not overflow int16, otherwise returns 0 and false.
+func AddInt16Checked(a, b int16) (int16, bool) {
+	c := int64(a) + int64(b)
+	if c < int64(MinInt16) || c > int64(MaxInt16) {
+		return 0, false
+	}
+	return int16(c), true
+}
+
+// SubInt16Checked returns a - b and true if the result does not overflow int16, otherwise returns 0 and false.
+func SubInt16Checked(a, b int16) (int16, bool) {
+	c := int64(a) - int64(b)
+	if c < int64(MinInt16) || c > int64(MaxInt16) {
+		return 0, false
+	}
+	return int16(c), true
+}
+
+// MulInt16Checked returns a * b and true if the result does not overflow int16, otherwise returns 0 and false.
+func MulInt16Checked(a, b int16) (int16, bool) {
+	c := int64(a) * int64(b)
+	if c < int64(MinInt16) || c > int64(MaxInt16) {
+		return 0, false
+	}
+	return int16(c), true
+}
+
+// DivInt16Checked returns a / b and true if b is not zero and the result does not overflow int16 (that is MinInt16 / -1),
+// otherwise returns 0 and false.
+func DivInt16Checked(a, b int16) (int16, bool) {
+	if b == 0 || (a == MinInt16 && b == -1) {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddInt32Checked returns a + b and true if the result does not overflow int32, otherwise returns 0 and false.
+func AddInt32Checked(a, b int32) (int32, bool) {
+	c := int64(a) + int64(b)
+	if c < int64(MinInt32) || c > int64(MaxInt32) {
+		return 0, false
+	}
+	return int32(c), true
+}
+
+// SubInt32Checked returns a - b and true if the result does not overflow int32, otherwise returns 0 and false.
+func SubInt32Checked(a, b int32) (int32, bool) {
+	c := int64(a) - int64(b)
+	if c < int64(MinInt32) || c > int64(MaxInt32) {
+		return 0, false
+	}
+	return int32(c), true
+}
+
+// MulInt32Checked returns a * b and true if the result does not overflow int32, otherwise returns 0 and false.
+func MulInt32Checked(a, b int32) (int32, bool) {
+	c := int64(a) * int64(b)
+	if c < int64(MinInt32) || c > int64(MaxInt32) {
+		return 0, false
+	}
+	return int32(c), true
+}
+
+// DivInt32Checked returns a / b and true if b is not zero and the result does not overflow int32 (that is MinInt32 / -1),
+// otherwise returns 0 and false.
+func DivInt32Checked(a, b int32) (int32, bool) {
+	if b == 0 || (a == MinInt32 && b == -1) {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddInt64Checked returns a + b and true if the result does not overflow int64, otherwise returns 0 and false.
+func AddInt64Checked(a, b int64) (int64, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// SubInt64Checked returns a - b and true if the result does not overflow int64, otherwise returns 0 and false.
+func SubInt64Checked(a, b int64) (int64, bool) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// MulInt64Checked returns a * b and true if the result does not overflow int64, otherwise returns 0 and false.
+func MulInt64Checked(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == MinInt64) || (b == -1 && a == MinInt64) {
+		return 0, false
+	}
+	return c, true
+}
+
+// DivInt64Checked returns a / b and true if b is not zero and the result does not overflow int64 (that is MinInt64 / -1),
+// otherwise returns 0 and false.
+func DivInt64Checked(a, b int64) (int64, bool) {
+	if b == 0 || (a == MinInt64 && b == -1) {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddUintChecked returns a + b and true if the result does not overflow uint, otherwise returns 0 and false.
+func AddUintChecked(a, b uint) (uint, bool) {
+	c := a + b
+	if c < a {
+		return 0, false
+	}
+	return c, true
+}
+
+// SubUintChecked returns a - b and true if the result does not underflow uint, otherwise returns 0 and false.
+func SubUintChecked(a, b uint) (uint, bool) {
+	if a < b {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulUintChecked returns a * b and true if the result does not overflow uint, otherwise returns 0 and false.
+func MulUintChecked(a, b uint) (uint, bool) {
+	c := a * b
+	if a != 0 && c/a != b {
+		return 0, false
+	}
+	return c, true
+}
+
+// DivUintChecked returns a / b and true if b is not zero, otherwise returns 0 and false.
+func DivUintChecked(a, b uint) (uint, bool) {
+	if b == 0 {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddUint8Checked returns a + b and true if the result does not overflow uint8, otherwise returns 0 and false.
+func AddUint8Checked(a, b uint8) (uint8, bool) {
+	c := uint64(a) + uint64(b)
+	if c > uint64(MaxUint8) {
+		return 0, false
+	}
+	return uint8(c), true
+}
+
+// SubUint8Checked returns a - b and true if the result does not underflow uint8, otherwise returns 0 and false.
+func SubUint8Checked(a, b uint8) (uint8, bool) {
+	if a < b {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulUint8Checked returns a * b and true if the result does not overflow uint8, otherwise returns 0 and false.
+func MulUint8Checked(a, b uint8) (uint8, bool) {
+	c := uint64(a) * uint64(b)
+	if c > uint64(MaxUint8) {
+		return 0, false
+	}
+	return uint8(c), true
+}
+
+// DivUint8Checked returns a / b and true if b is not zero, otherwise returns 0 and false.
+func DivUint8Checked(a, b uint8) (uint8, bool) {
+	if b == 0 {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddUint16Checked returns a + b and true if the result does not overflow uint16, otherwise returns 0 and false.
+func AddUint16Checked(a, b uint16) (uint16, bool) {
+	c := uint64(a) + uint64(b)
+	if c > uint64(MaxUint16) {
+		return 0, false
+	}
+	return uint16(c), true
+}
+
+// SubUint16Checked returns a - b and true if the result does not underflow uint16, otherwise returns 0 and false.
+func SubUint16Checked(a, b uint16) (uint16, bool) {
+	if a < b {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulUint16Checked returns a * b and true if the result does not overflow uint16, otherwise returns 0 and false.
+func MulUint16Checked(a, b uint16) (uint16, bool) {
+	c := uint64(a) * uint64(b)
+	if c > uint64(MaxUint16) {
+		return 0, false
+	}
+	return uint16(c), true
+}
+
+// DivUint16Checked returns a / b and true if b is not zero, otherwise returns 0 and false.
+func DivUint16Checked(a, b uint16) (uint16, bool) {
+	if b == 0 {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddUint32Checked returns a + b and true if the result does not overflow uint32, otherwise returns 0 and false.
+func AddUint32Checked(a, b uint32) (uint32, bool) {
+	c := uint64(a) + uint64(b)
+	if c > uint64(MaxUint32) {
+		return 0, false
+	}
+	return uint32(c), true
+}
+
+// SubUint32Checked returns a - b and true if the result does not underflow uint32, otherwise returns 0 and false.
+func SubUint32Checked(a, b uint32) (uint32, bool) {
+	if a < b {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulUint32Checked returns a * b and true if the result does not overflow uint32, otherwise returns 0 and false.
+func MulUint32Checked(a, b uint32) (uint32, bool) {
+	c := uint64(a) * uint64(b)
+	if c > uint64(MaxUint32) {
+		return 0, false
+	}
+	return uint32(c), true
+}
+
+// DivUint32Checked returns a / b and true if b is not zero, otherwise returns 0 and false.
+func DivUint32Checked(a, b uint32) (uint32, bool) {
+	if b == 0 {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// AddUint64Checked returns a + b and true if the result does not overflow uint64, otherwise returns 0 and false.
+func AddUint64Checked(a, b uint64) (uint64, bool) {
+	c := a + b
+	if c < a {
+		return 0, false
+	}
+	return c, true
+}
+
+// SubUint64Checked returns a - b and true if the result does not underflow uint64, otherwise returns 0 and false.
+func SubUint64Checked(a, b uint64) (uint64, bool) {
+	if a < b {
+		return 0, false
+	}
+	return a - b, true
+}
+
+// MulUint64Checked returns a * b and true if the result does not overflow uint64, otherwise returns 0 and false.
+func MulUint64Checked(a, b uint64) (uint64, bool) {
+	c := a * b
+	if a != 0 && c/a != b {
+		return 0, false
+	}
+	return c, true
+}
+
+// DivUint64Checked returns a / b and true if b is not zero, otherwise returns 0 and false.
+func DivUint64Checked(a, b uint64) (uint64, bool) {
+	if b == 0 {
+		return 0, false
+	}
+	return a / b, true
+}
+
+// saturating
+
+// AddIntSaturating returns a + b, and clamps the result to the range of int if it overflows.
+func AddIntSaturating(a, b int) int {
+	if c, ok := AddIntChecked(a, b); ok {
+		return c
+	}
+	if b > 0 {
+		return maxInt
+	}
+	return minInt
+}
+
+// SubIntSaturating returns a - b, and clamps the result to the range of int if it overflows.
+func SubIntSaturating(a, b int) int {
+	if c, ok := SubIntChecked(a, b); ok {
+		return c
+	}
+	if b < 0 {
+		return maxInt
+	}
+	return minInt
+}
+
+// MulIntSaturating returns a * b, and clamps the result to the range of int if it overflows.
+func MulIntSaturating(a, b int) int {
+	if c, ok := MulIntChecked(a, b); ok {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return minInt
+	}
+	return maxInt
+}
+
+// AddInt8Saturating returns a + b, and clamps the result to [MinInt8, MaxInt8] if it overflows.
+func AddInt8Saturating(a, b int8) int8 {
+	if c, ok := AddInt8Checked(a, b); ok {
+		return c
+	}
+	if b > 0 {
+		return MaxInt8
+	}
+	return MinInt8
+}
+
+// SubInt8Saturating returns a - b, and clamps the result to [MinInt8, MaxInt8] if it overflows.
+func SubInt8Saturating(a, b int8) int8 {
+	if c, ok := SubInt8Checked(a, b); ok {
+		return c
+	}
+	if b < 0 {
+		return MaxInt8
+	}
+	return MinInt8
+}
+
+// MulInt8Saturating returns a * b, and clamps the result to [MinInt8, MaxInt8] if it overflows.
+func MulInt8Saturating(a, b int8) int8 {
+	if c, ok := MulInt8Checked(a, b); ok {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return MinInt8
+	}
+	return MaxInt8
+}
+
+// AddInt16Saturating returns a + b, and clamps the result to [MinInt16, MaxInt16] if it overflows.
+func AddInt16Saturating(a, b int16) int16 {
+	if c, ok := AddInt16Checked(a, b); ok {
+		return c
+	}
+	if b > 0 {
+		return MaxInt16
+	}
+	return MinInt16
+}
+
+// SubInt16Saturating returns a - b, and clamps the result to [MinInt16, MaxInt16] if it overflows.
+func SubInt16Saturating(a, b int16) int16 {
+	if c, ok := SubInt16Checked(a, b); ok {
+		return c
+	}
+	if b < 0 {
+		return MaxInt16
+	}
+	return MinInt16
+}
+
+// MulInt16Saturating returns a * b, and clamps the result to [MinInt16, MaxInt16] if it overflows.
+func MulInt16Saturating(a, b int16) int16 {
+	if c, ok := MulInt16Checked(a, b); ok {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return MinInt16
+	}
+	return MaxInt16
+}
+
+// AddInt32Saturating returns a + b, and clamps the result to [MinInt32, MaxInt32] if it overflows.
+func AddInt32Saturating(a, b int32) int32 {
+	if c, ok := AddInt32Checked(a, b); ok {
+		return c
+	}
+	if b > 0 {
+		return MaxInt32
+	}
+	return MinInt32
+}
+
+// SubInt32Saturating returns a - b, and clamps the result to [MinInt32, MaxInt32] if it overflows.
+func SubInt32Saturating(a, b int32) int32 {
+	if c, ok := SubInt32Checked(a, b); ok {
+		return c
+	}
+	if b < 0 {
+		return MaxInt32
+	}
+	return MinInt32
+}
+
+// MulInt32Saturating returns a * b, and clamps the result to [MinInt32, MaxInt32] if it overflows.
+func MulInt32Saturating(a, b int32) int32 {
+	if c, ok := MulInt32Checked(a, b); ok {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return MinInt32
+	}
+	return MaxInt32
+}
+
+// AddInt64Saturating returns a + b, and clamps the result to [MinInt64, MaxInt64] if it overflows.
+func AddInt64Saturating(a, b int64) int64 {
+	if c, ok := AddInt64Checked(a, b); ok {
+		return c
+	}
+	if b > 0 {
+		return MaxInt64
+	}
+	return MinInt64
+}
+
+// SubInt64Saturating returns a - b, and clamps the result to [MinInt64, MaxInt64] if it overflows.
+func SubInt64Saturating(a, b int64) int64 {
+	if c, ok := SubInt64Checked(a, b); ok {
+		return c
+	}
+	if b < 0 {
+		return MaxInt64
+	}
+	return MinInt64
+}
+
+// MulInt64Saturating returns a * b, and clamps the result to [MinInt64, MaxInt64] if it overflows.
+func MulInt64Saturating(a, b int64) int64 {
+	if c, ok := MulInt64Checked(a, b); ok {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return MinInt64
+	}
+	return MaxInt64
+}
+
+// AddUintSaturating returns a + b, and clamps the result to the maximum uint if it overflows.
+func AddUintSaturating(a, b uint) uint {
+	if c, ok := AddUintChecked(a, b); ok {
+		return c
+	}
+	return maxUint
+}
+
+// SubUintSaturating returns a - b, and clamps the result to 0 if it underflows.
+func SubUintSaturating(a, b uint) uint {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// MulUintSaturating returns a * b, and clamps the result to the maximum uint if it overflows.
+func MulUintSaturating(a, b uint) uint {
+	if c, ok := MulUintChecked(a, b); ok {
+		return c
+	}
+	return maxUint
+}
+
+// AddUint8Saturating returns a + b, and clamps the result to MaxUint8 if it overflows.
+func AddUint8Saturating(a, b uint8) uint8 {
+	if c, ok := AddUint8Checked(a, b); ok {
+		return c
+	}
+	return MaxUint8
+}
+
+// SubUint8Saturating returns a - b, and clamps the result to 0 if it underflows.
+func SubUint8Saturating(a, b uint8) uint8 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// MulUint8Saturating returns a * b, and clamps the result to MaxUint8 if it overflows.
+func MulUint8Saturating(a, b uint8) uint8 {
+	if c, ok := MulUint8Checked(a, b); ok {
+		return c
+	}
+	return MaxUint8
+}
+
+// AddUint16Saturating returns a + b, and clamps the result to MaxUint16 if it overflows.
+func AddUint16Saturating(a, b uint16) uint16 {
+	if c, ok := AddUint16Checked(a, b); ok {
+		return c
+	}
+	return MaxUint16
+}
+
+// SubUint16Saturating returns a - b, and clamps the result to 0 if it underflows.
+func SubUint16Saturating(a, b uint16) uint16 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// MulUint16Saturating returns a * b, and clamps the result to MaxUint16 if it overflows.
+func MulUint16Saturating(a, b uint16) uint16 {
+	if c, ok := MulUint16Checked(a, b); ok {
+		return c
+	}
+	return MaxUint16
+}
+
+// AddUint32Saturating returns a + b, and clamps the result to MaxUint32 if it overflows.
+func AddUint32Saturating(a, b uint32) uint32 {
+	if c, ok := AddUint32Checked(a, b); ok {
+		return c
+	}
+	return MaxUint32
+}
+
+// SubUint32Saturating returns a - b, and clamps the result to 0 if it underflows.
+func SubUint32Saturating(a, b uint32) uint32 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// MulUint32Saturating returns a * b, and clamps the result to MaxUint32 if it overflows.
+func MulUint32Saturating(a, b uint32) uint32 {
+	if c, ok := MulUint32Checked(a, b); ok {
+		return c
+	}
+	return MaxUint32
+}
+
+// AddUint64Saturating returns a + b, and clamps the result to MaxUint64 if it overflows.
+func AddUint64Saturating(a, b uint64) uint64 {
+	if c, ok := AddUint64Checked(a, b); ok {
+		return c
+	}
+	return MaxUint64
+}
+
+// SubUint64Saturating returns a - b, and clamps the result to 0 if it underflows.
+func SubUint64Saturating(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// MulUint64Saturating returns a * b, and clamps the result to MaxUint64 if it overflows.
+func MulUint64Saturating(a, b uint64) uint64 {
+	if c, ok := MulUint64Checked(a, b); ok {
+		return c
+	}
+	return MaxUint64
+}
+
+// conversion
+
+// ToInt converts an int64 to int, returns error if the value is out of the range of int.
+func ToInt(i int64) (int, error) {
+	if i < int64(minInt) || i > int64(maxInt) {
+		return 0, errIntegerOutOfRange
+	}
+	return int(i), nil
+}
+
+// ToInt8 converts an int64 to int8, returns error if the value is out of the range of int8.
+func ToInt8(i int64) (int8, error) {
+	if i < int64(MinInt8) || i > int64(MaxInt8) {
+		return 0, errIntegerOutOfRange
+	}
+	return int8(i), nil
+}
+
+// ToInt16 converts an int64 to int16, returns error if the value is out of the range of int16.
+func ToInt16(i int64) (int16, error) {
+	if i < int64(MinInt16) || i > int64(MaxInt16) {
+		return 0, errIntegerOutOfRange
+	}
+	return int16(i), nil
+}
+
+// ToInt32 converts an int64 to int32, returns error if the value is out of the range of int32.
+func ToInt32(i int64) (int32, error) {
+	if i < int64(MinInt32) || i > int64(MaxInt32) {
+		return 0, errIntegerOutOfRange
+	}
+	return int32(i), nil
+}
+
+// ToUint converts an uint64 to uint, returns error if the value is out of the range of uint.
+func ToUint(u uint64) (uint, error) {
+	if u > uint64(maxUint) {
+		return 0, errIntegerOutOfRange
+	}
+	return uint(u), nil
+}
+
+// ToUint8 converts an uint64 to uint8, returns error if the value is out of the range of uint8.
+func ToUint8(u uint64) (uint8, error) {
+	if u > uint64(MaxUint8) {
+		return 0, errIntegerOutOfRange
+	}
+	return uint8(u), nil
+}
+
+// ToUint16 converts an uint64 to uint16, returns error if the value is out of the range of uint16.
+func ToUint16(u uint64) (uint16, error) {
+	if u > uint64(MaxUint16) {
+		return 0, errIntegerOutOfRange
+	}
+	return uint16(u), nil
+}
+
+// ToUint32 converts an uint64 to uint32, returns error if the value is out of the range of uint32.
+func ToUint32(u uint64) (uint32, error) {
+	if u > uint64(MaxUint32) {
+		return 0, errIntegerOutOfRange
+	}
+	return uint32(u), nil
+}
+
+// Int64ToUint64 converts an int64 to uint64, returns error if the value is negative.
+func Int64ToUint64(i int64) (uint64, error) {
+	if i < 0 {
+		return 0, errIntegerOutOfRange
+	}
+	return uint64(i), nil
+}
+
+// Uint64ToInt64 converts an uint64 to int64, returns error if the value is larger than MaxInt64.
+func Uint64ToInt64(u uint64) (int64, error) {
+	if u > uint64(MaxInt64) {
+		return 0, errIntegerOutOfRange
+	}
+	return int64(u), nil
+}
@@ -0,0 +1,248 @@
+package xnumber
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"testing"
+)
+
+func TestCheckedSigned(t *testing.T) {
+	type result struct {
+		v  int64
+		ok bool
+	}
+	r := func(v interface{}, ok bool) result {
+		switch v := v.(type) {
+		case int:
+			return result{int64(v), ok}
+		case int8:
+			return result{int64(v), ok}
+		case int16:
+			return result{int64(v), ok}
+		case int32:
+			return result{int64(v), ok}
+		default:
+			return result{v.(int64), ok}
+		}
+	}
+
+	for _, tc := range []struct {
+		give result
+		want result
+	}{
+		// int8
+		{r(AddInt8Checked(100, 27)), result{127, true}},
+		{r(AddInt8Checked(100, 28)), result{0, false}},
+		{r(AddInt8Checked(-100, -28)), result{-128, true}},
+		{r(AddInt8Checked(-100, -29)), result{0, false}},
+		{r(SubInt8Checked(-100, 28)), result{-128, true}},
+		{r(SubInt8Checked(-100, 29)), result{0, false}},
+		{r(SubInt8Checked(0, -128)), result{0, false}},
+		{r(MulInt8Checked(-16, 8)), result{-128, true}},
+		{r(MulInt8Checked(16, 8)), result{0, false}},
+		{r(DivInt8Checked(-128, -1)), result{0, false}},
+		{r(DivInt8Checked(-128, 2)), result{-64, true}},
+		{r(DivInt8Checked(1, 0)), result{0, false}},
+		// int16
+		{r(AddInt16Checked(32000, 767)), result{32767, true}},
+		{r(AddInt16Checked(32000, 768)), result{0, false}},
+		{r(SubInt16Checked(-32768, 1)), result{0, false}},
+		{r(MulInt16Checked(256, 128)), result{0, false}},
+		{r(MulInt16Checked(-256, 128)), result{-32768, true}},
+		{r(DivInt16Checked(MinInt16, -1)), result{0, false}},
+		// int32
+		{r(AddInt32Checked(MaxInt32, 0)), result{int64(MaxInt32), true}},
+		{r(AddInt32Checked(MaxInt32, 1)), result{0, false}},
+		{r(SubInt32Checked(MinInt32, 1)), result{0, false}},
+		{r(MulInt32Checked(65536, 32768)), result{0, false}},
+		{r(MulInt32Checked(-65536, 32768)), result{int64(MinInt32), true}},
+		{r(DivInt32Checked(7, 2)), result{3, true}},
+		// int64
+		{r(AddInt64Checked(MaxInt64-1, 1)), result{MaxInt64, true}},
+		{r(AddInt64Checked(MaxInt64, 1)), result{0, false}},
+		{r(AddInt64Checked(MinInt64, -1)), result{0, false}},
+		{r(AddInt64Checked(MinInt64, MaxInt64)), result{-1, true}},
+		{r(SubInt64Checked(MinInt64, 1)), result{0, false}},
+		{r(SubInt64Checked(0, MinInt64)), result{0, false}},
+		{r(SubInt64Checked(-1, MinInt64)), result{MaxInt64, true}},
+		{r(SubInt64Checked(MaxInt64, -1)), result{0, false}},
+		{r(MulInt64Checked(0, MinInt64)), result{0, true}},
+		{r(MulInt64Checked(MinInt64, 1)), result{MinInt64, true}},
+		{r(MulInt64Checked(MinInt64, -1)), result{0, false}},
+		{r(MulInt64Checked(-1, MinInt64)), result{0, false}},
+		{r(MulInt64Checked(1<<32, 1<<31)), result{0, false}},
+		{r(MulInt64Checked(-(1 << 32), 1<<31)), result{MinInt64, true}},
+		{r(MulInt64Checked(3037000500, 3037000500)), result{0, false}},
+		{r(MulInt64Checked(3037000499, -3037000499)), result{-9223372030926249001, true}},
+		{r(DivInt64Checked(MinInt64, -1)), result{0, false}},
+		{r(DivInt64Checked(MinInt64, 1)), result{MinInt64, true}},
+		{r(DivInt64Checked(0, 0)), result{0, false}},
+		// int
+		{r(AddIntChecked(maxInt, 1)), result{0, false}},
+		{r(AddIntChecked(1, 2)), result{3, true}},
+		{r(SubIntChecked(minInt, 1)), result{0, false}},
+		{r(MulIntChecked(minInt, -1)), result{0, false}},
+		{r(MulIntChecked(-3, 4)), result{-12, true}},
+		{r(DivIntChecked(minInt, -1)), result{0, false}},
+		{r(DivIntChecked(-9, 3)), result{-3, true}},
+	} {
+		xtesting.Equal(t, tc.give, tc.want)
+	}
+}
+
+func TestCheckedUnsigned(t *testing.T) {
+	type result struct {
+		v  uint64
+		ok bool
+	}
+	r := func(v interface{}, ok bool) result {
+		switch v := v.(type) {
+		case uint:
+			return result{uint64(v), ok}
+		case uint8:
+			return result{uint64(v), ok}
+		case uint16:
+			return result{uint64(v), ok}
+		case uint32:
+			return result{uint64(v), ok}
+		default:
+			return result{v.(uint64), ok}
+		}
+	}
+
+	for _, tc := range []struct {
+		give result
+		want result
+	}{
+		// uint8
+		{r(AddUint8Checked(200, 55)), result{255, true}},
+		{r(AddUint8Checked(200, 56)), result{0, false}},
+		{r(SubUint8Checked(1, 1)), result{0, true}},
+		{r(SubUint8Checked(1, 2)), result{0, false}},
+		{r(MulUint8Checked(15, 17)), result{255, true}},
+		{r(MulUint8Checked(16, 16)), result{0, false}},
+		{r(DivUint8Checked(255, 2)), result{127, true}},
+		{r(DivUint8Checked(1, 0)), result{0, false}},
+		// uint16
+		{r(AddUint16Checked(MaxUint16, 1)), result{0, false}},
+		{r(SubUint16Checked(0, 1)), result{0, false}},
+		{r(MulUint16Checked(256, 256)), result{0, false}},
+		{r(MulUint16Checked(255, 257)), result{65535, true}},
+		// uint32
+		{r(AddUint32Checked(MaxUint32, 1)), result{0, false}},
+		{r(MulUint32Checked(65536, 65536)), result{0, false}},
+		{r(MulUint32Checked(65535, 65537)), result{uint64(MaxUint32), true}},
+		{r(DivUint32Checked(9, 0)), result{0, false}},
+		// uint64
+		{r(AddUint64Checked(MaxUint64-1, 1)), result{MaxUint64, true}},
+		{r(AddUint64Checked(MaxUint64, 1)), result{0, false}},
+		{r(SubUint64Checked(MaxUint64, MaxUint64)), result{0, true}},
+		{r(SubUint64Checked(0, MaxUint64)), result{0, false}},
+		{r(MulUint64Checked(0, MaxUint64)), result{0, true}},
+		{r(MulUint64Checked(1<<32, 1<<32)), result{0, false}},
+		{r(MulUint64Checked(4294967295, 4294967297)), result{MaxUint64, true}},
+		{r(DivUint64Checked(MaxUint64, 1)), result{MaxUint64, true}},
+		// uint
+		{r(AddUintChecked(maxUint, 1)), result{0, false}},
+		{r(SubUintChecked(3, 2)), result{1, true}},
+		{r(MulUintChecked(maxUint, 2)), result{0, false}},
+		{r(DivUintChecked(8, 0)), result{0, false}},
+	} {
+		xtesting.Equal(t, tc.give, tc.want)
+	}
+}
+
+func TestSaturating(t *testing.T) {
+	for _, tc := range []struct {
+		give interface{}
+		want interface{}
+	}{
+		{AddInt8Saturating(100, 100), MaxInt8},
+		{AddInt8Saturating(-100, -100), MinInt8},
+		{AddInt8Saturating(-100, 100), int8(0)},
+		{SubInt8Saturating(-100, 100), MinInt8},
+		{SubInt8Saturating(100, -100), MaxInt8},
+		{MulInt8Saturating(-100, 100), MinInt8},
+		{MulInt8Saturating(-100, -100), MaxInt8},
+		{MulInt8Saturating(-10, 10), int8(-100)},
+		{AddInt16Saturating(MaxInt16, 1), MaxInt16},
+		{SubInt16Saturating(MinInt16, 1), MinInt16},
+		{MulInt16Saturating(MinInt16, -1), MaxInt16},
+		{AddInt32Saturating(MinInt32, -1), MinInt32},
+		{MulInt32Saturating(MaxInt32, 2), MaxInt32},
+		{AddInt64Saturating(MaxInt64, MaxInt64), MaxInt64},
+		{AddInt64Saturating(MinInt64, -1), MinInt64},
+		{SubInt64Saturating(0, MinInt64), MaxInt64},
+		{SubInt64Saturating(MinInt64, MaxInt64), MinInt64},
+		{MulInt64Saturating(MinInt64, -1), MaxInt64},
+		{MulInt64Saturating(MinInt64, 2), MinInt64},
+		{MulInt64Saturating(1<<40, -(1 << 40)), MinInt64},
+		{MulInt64Saturating(3, 4), int64(12)},
+		{AddIntSaturating(maxInt, 1), maxInt},
+		{SubIntSaturating(minInt, 1), minInt},
+		{MulIntSaturating(maxInt, -2), minInt},
+
+		{AddUint8Saturating(200, 100), MaxUint8},
+		{SubUint8Saturating(100, 200), uint8(0)},
+		{MulUint8Saturating(16, 16), MaxUint8},
+		{MulUint8Saturating(15, 17), uint8(255)},
+		{AddUint16Saturating(MaxUint16, 1), MaxUint16},
+		{SubUint16Saturating(5, 3), uint16(2)},
+		{MulUint32Saturating(MaxUint32, 2), MaxUint32},
+		{AddUint64Saturating(MaxUint64, 1), MaxUint64},
+		{SubUint64Saturating(0, 1), uint64(0)},
+		{MulUint64Saturating(1<<32, 1<<32), MaxUint64},
+		{AddUintSaturating(maxUint, maxUint), maxUint},
+		{SubUintSaturating(1, 2), uint(0)},
+		{MulUintSaturating(maxUint, 3), maxUint},
+	} {
+		xtesting.Equal(t, tc.give, tc.want)
+	}
+}
+
+func TestToInt(t *testing.T) {
+	type result struct {
+		v   interface{}
+		err error
+	}
+	r := func(v interface{}, err error) result { return result{v, err} }
+
+	for _, tc := range []struct {
+		give result
+		want result
+	}{
+		{r(ToInt8(127)), result{int8(127), nil}},
+		{r(ToInt8(-128)), result{int8(-128), nil}},
+		{r(ToInt8(128)), result{int8(0), errIntegerOutOfRange}},
+		{r(ToInt8(-129)), result{int8(0), errIntegerOutOfRange}},
+		{r(ToInt16(32767)), result{int16(32767), nil}},
+		{r(ToInt16(-32769)), result{int16(0), errIntegerOutOfRange}},
+		{r(ToInt32(-1)), result{int32(-1), nil}},
+		{r(ToInt32(int64(MaxInt32) + 1)), result{int32(0), errIntegerOutOfRange}},
+		{r(ToInt32(int64(MinInt32))), result{MinInt32, nil}},
+		{r(ToInt(12)), result{12, nil}},
+		{r(ToUint8(255)), result{uint8(255), nil}},
+		{r(ToUint8(256)), result{uint8(0), errIntegerOutOfRange}},
+		{r(ToUint16(65536)), result{uint16(0), errIntegerOutOfRange}},
+		{r(ToUint32(uint64(MaxUint32))), result{MaxUint32, nil}},
+		{r(ToUint32(uint64(MaxUint32) + 1)), result{uint32(0), errIntegerOutOfRange}},
+		{r(ToUint(12)), result{uint(12), nil}},
+		{r(Int64ToUint64(MaxInt64)), result{uint64(MaxInt64), nil}},
+		{r(Int64ToUint64(-1)), result{uint64(0), errIntegerOutOfRange}},
+		{r(Uint64ToInt64(uint64(MaxInt64))), result{MaxInt64, nil}},
+		{r(Uint64ToInt64(uint64(MaxInt64) + 1)), result{int64(0), errIntegerOutOfRange}},
+	} {
+		xtesting.Equal(t, tc.give, tc.want)
+	}
+
+	if IntSize() == 32 {
+		_, err := ToInt(int64(MaxInt32) + 1)
+		xtesting.Equal(t, err, errIntegerOutOfRange)
+		_, err = ToUint(uint64(MaxUint32) + 1)
+		xtesting.Equal(t, err, errIntegerOutOfRange)
+	} else {
+		_, err := ToInt(MaxInt64)
+		xtesting.Nil(t, err)
+		_, err = ToUint(MaxUint64)
+		xtesting.Nil(t, err)
+	}
+}
@@ -0,0 +1,457 @@
+package xnumber
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal represents an arbitrary-precision fixed-point decimal number, which is stored as an unscaled big.Int and a scale,
+// that is unscaled * 10^(-scale). Decimal is immutable and can be used for money calculation without floating point error.
+// Note that the zero value of Decimal represents 0 and is ready to use.
+//
+// Decimal implements json.Marshaler, json.Unmarshaler, sql.Scanner and driver.Valuer like xtime.JsonDate, and it is marshaled
+// to a quoted string, such as "123.45", to keep the precision.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+const (
+	panicDecimalDivisionByZero = "xnumber: decimal division by zero"
+	panicDecimalNaNOrInf       = "xnumber: decimal could not be created from NaN or Inf"
+	panicDecimalNegativeScale  = "xnumber: decimal scale must be non-negative"
+	panicDecimalInvalidRatios  = "xnumber: decimal allocation ratios must be non-negative and have a positive sum"
+)
+
+var (
+	errInvalidDecimal = errors.New("xnumber: invalid decimal string")
+)
+
+// NewDecimal creates a Decimal from given unscaled value and scale, that is value * 10^(-scale).
+//
+// Example:
+// 	NewDecimal(12345, 2) // => 123.45
+// 	NewDecimal(-5, 3)    // => -0.005
+func NewDecimal(value int64, scale int32) Decimal {
+	if scale < 0 {
+		panic(panicDecimalNegativeScale)
+	}
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// NewDecimalFromFloat creates a Decimal from given float using its shortest decimal representation, so NewDecimalFromFloat(0.1)
+// returns exactly 0.1 rather than 0.1000000000000000055511151231257827.
+func NewDecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(panicDecimalNaNOrInf)
+	}
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64)) // always valid
+	return d
+}
+
+// ParseDecimal parses a string to Decimal, the string can have a sign, a decimal point and an exponent, the scale of the result
+// equals to the number of digits after the decimal point (adjusted by the exponent), and the exponent must be in the range
+// of int16.
+//
+// Example:
+// 	ParseDecimal("123.450") // => 123.450 (scale 3)
+// 	ParseDecimal("-.5")     // => -0.5
+// 	ParseDecimal("1.5e3")   // => 1500
+// 	ParseDecimal("1.2.3")   // => error
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+	if idx := strings.IndexAny(s, "eE"); idx != -1 {
+		e, err := strconv.ParseInt(s[idx+1:], 10, 16)
+		if err != nil {
+			return Decimal{}, errInvalidDecimal
+		}
+		mantissa, exp = s[:idx], e
+	}
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '+' || mantissa[0] == '-') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	intPart, fracPart := mantissa, ""
+	if idx := strings.IndexByte(mantissa, '.'); idx != -1 {
+		intPart, fracPart = mantissa[:idx], mantissa[idx+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" {
+		return Decimal{}, errInvalidDecimal
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return Decimal{}, errInvalidDecimal
+		}
+	}
+
+	value, _ := new(big.Int).SetString(sign+digits, 10) // always valid
+	scale := int64(len(fracPart)) - exp
+	if scale < 0 {
+		value.Mul(value, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+// ParseDecimalOr parses a string to Decimal with a fallback value.
+func ParseDecimalOr(s string, o Decimal) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return o
+	}
+	return d
+}
+
+// pow10 returns 10^n in big.Int.
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// roundQuo returns n/d rounded to integer using given RoundingMode.
+func roundQuo(n, d *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	negative := n.Sign() != d.Sign()
+	twice := new(big.Int).Abs(r)
+	half := twice.Lsh(twice, 1).Cmp(new(big.Int).Abs(d)) // compare 2|r| with |d|
+	increment := false
+	switch mode {
+	case RoundHalfUp:
+		increment = half >= 0
+	case RoundHalfDown:
+		increment = half > 0
+	case RoundHalfEven:
+		increment = half > 0 || (half == 0 && q.Bit(0) == 1)
+	case RoundUp:
+		increment = true
+	case RoundDown:
+		increment = false
+	case RoundCeiling:
+		increment = !negative
+	case RoundFloor:
+		increment = negative
+	}
+	if increment {
+		if negative {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// unscaled returns the unscaled value, and returns 0 for zero value Decimal.
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// rescaled returns the unscaled value which is rescaled to given larger scale.
+func (d Decimal) rescaled(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.unscaled()
+	}
+	return new(big.Int).Mul(d.unscaled(), pow10(int64(scale-d.scale)))
+}
+
+// Unscaled returns a copy of the unscaled value of Decimal.
+func (d Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(d.unscaled())
+}
+
+// Scale returns the scale of Decimal, that is the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0, and +1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// IsZero checks whether the Decimal is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.unscaled()), scale: d.scale}
+}
+
+// Add returns d + d2, the scale of the result is the larger scale of d and d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	scale := maxInt32(d.scale, d2.scale)
+	return Decimal{value: new(big.Int).Add(d.rescaled(scale), d2.rescaled(scale)), scale: scale}
+}
+
+// Sub returns d - d2, the scale of the result is the larger scale of d and d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	scale := maxInt32(d.scale, d2.scale)
+	return Decimal{value: new(big.Int).Sub(d.rescaled(scale), d2.rescaled(scale)), scale: scale}
+}
+
+// Mul returns d * d2 exactly, the scale of the result is the sum of scales of d and d2, please use MulRound or Round if a
+// fixed scale is needed.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.unscaled(), d2.unscaled()), scale: d.scale + d2.scale}
+}
+
+// MulRound returns d * d2 rounded to given scale using given RoundingMode.
+//
+// Example:
+// 	price, _ := ParseDecimal("19.99")
+// 	price.MulRound(NewDecimal(7, 2), 2, RoundHalfUp) // => 1.40 (19.99 * 0.07 = 1.3993)
+func (d Decimal) MulRound(d2 Decimal, scale int32, mode RoundingMode) Decimal {
+	return d.Mul(d2).Round(scale, mode)
+}
+
+// Div returns d / d2 rounded to given scale using given RoundingMode, it panics if d2 is zero.
+//
+// Example:
+// 	NewDecimal(10, 0).Div(NewDecimal(3, 0), 2, RoundHalfUp) // => 3.33
+// 	NewDecimal(2, 0).Div(NewDecimal(3, 0), 2, RoundDown)    // => 0.66
+func (d Decimal) Div(d2 Decimal, scale int32, mode RoundingMode) Decimal {
+	if d2.IsZero() {
+		panic(panicDecimalDivisionByZero)
+	}
+	if scale < 0 {
+		panic(panicDecimalNegativeScale)
+	}
+	// d/d2 = (v1 * 10^-s1) / (v2 * 10^-s2) = (v1 * 10^(scale+s2-s1)) / v2 * 10^-scale
+	n, den := d.Unscaled(), d2.Unscaled()
+	if shift := int64(scale) + int64(d2.scale) - int64(d.scale); shift >= 0 {
+		n.Mul(n, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{value: roundQuo(n, den, mode), scale: scale}
+}
+
+// Round rounds Decimal to given scale using given RoundingMode, trailing zeros are appended if the given scale is larger.
+//
+// Example:
+// 	NewDecimal(12345, 3).Round(2, RoundHalfUp)   // => 12.35
+// 	NewDecimal(12345, 3).Round(2, RoundHalfEven) // => 12.34
+// 	NewDecimal(5, 1).Round(3, RoundHalfUp)       // => 0.500
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale < 0 {
+		panic(panicDecimalNegativeScale)
+	}
+	if scale >= d.scale {
+		return Decimal{value: d.rescaled(scale), scale: scale}
+	}
+	return Decimal{value: roundQuo(d.unscaled(), pow10(int64(d.scale-scale)), mode), scale: scale}
+}
+
+// Cmp compares d and d2, returns -1 if d < d2, 0 if d == d2, and +1 if d > d2. Note that the scales are not compared, so 1.0
+// equals to 1.00.
+func (d Decimal) Cmp(d2 Decimal) int {
+	scale := maxInt32(d.scale, d2.scale)
+	return d.rescaled(scale).Cmp(d2.rescaled(scale))
+}
+
+// Equal checks whether d == d2 in value, the scales are not compared.
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan checks whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan checks whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Float64 returns the nearest float64 value of Decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns the string of Decimal in plain notation, and the trailing zeros of the scale are kept.
+//
+// Example:
+// 	NewDecimal(12340, 2).String() // => 123.40
+// 	NewDecimal(-5, 3).String()    // => -0.005
+func (d Decimal) String() string {
+	value := d.unscaled()
+	digits := new(big.Int).Abs(value).String()
+	if d.scale > 0 {
+		if n := int(d.scale) + 1 - len(digits); n > 0 {
+			digits = strings.Repeat("0", n) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if value.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// maxInt32 returns the larger one of a and b.
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// ==========
+// allocation
+// ==========
+
+// Allocate allocates Decimal to parts by given ratios without losing any unit of the current scale (such as cents), the
+// remaining units are distributed one by one to the parts in order, so the sum of the results always equals to d. It panics
+// if ratios contain negative values or their sum is not positive.
+//
+// Example:
+// 	NewDecimal(5, 2).Allocate([]int64{3, 7})      // => [0.02, 0.03]
+// 	NewDecimal(100, 2).Allocate([]int64{1, 1, 1}) // => [0.34, 0.33, 0.33]
+func (d Decimal) Allocate(ratios []int64) []Decimal {
+	total := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			panic(panicDecimalInvalidRatios)
+		}
+		total.Add(total, big.NewInt(r))
+	}
+	if total.Sign() <= 0 {
+		panic(panicDecimalInvalidRatios)
+	}
+
+	value := d.unscaled()
+	results := make([]*big.Int, len(ratios))
+	remainder := new(big.Int).Set(value)
+	for i, r := range ratios {
+		results[i] = new(big.Int).Mul(value, big.NewInt(r))
+		results[i].Quo(results[i], total) // truncated toward zero
+		remainder.Sub(remainder, results[i])
+	}
+	unit := big.NewInt(int64(remainder.Sign()))
+	for i := 0; i < len(results) && remainder.Sign() != 0; i++ {
+		if ratios[i] > 0 {
+			results[i].Add(results[i], unit)
+			remainder.Sub(remainder, unit)
+		}
+	}
+
+	parts := make([]Decimal, len(results))
+	for i, v := range results {
+		parts[i] = Decimal{value: v, scale: d.scale}
+	}
+	return parts
+}
+
+// Split splits Decimal to n equal parts without losing any unit of the current scale, see Allocate for details.
+//
+// Example:
+// 	NewDecimal(1000, 2).Split(3) // => [3.34, 3.33, 3.33]
+func (d Decimal) Split(n int) []Decimal {
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return d.Allocate(ratios)
+}
+
+// ===================
+// marshal & unmarshal
+// ===================
+
+var (
+	errUnmarshalDecimal = errors.New("xnumber: given bytes could not be unmarshalled to Decimal")
+	errScanDecimal      = errors.New("xnumber: value could not be scanned to Decimal")
+)
+
+// MarshalJSON marshals the decimal value to json bytes as a quoted string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	str := "\"" + d.String() + "\""
+	return []byte(str), nil
+}
+
+// UnmarshalJSON unmarshals the decimal value from json bytes, both json string and json number are supported.
+func (d *Decimal) UnmarshalJSON(bytes []byte) error {
+	str := string(bytes)
+	if str == "null" {
+		return nil
+	}
+	if len(str) >= 2 && str[0] == '"' && str[len(str)-1] == '"' {
+		str = str[1 : len(str)-1]
+	}
+	dec, err := ParseDecimal(str)
+	if err != nil {
+		return errUnmarshalDecimal
+	}
+	*d = dec
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, the decimal value is marshaled in plain notation.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, the text is parsed by ParseDecimal.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	dec, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = dec
+	return nil
+}
+
+// Scan implementations sql.Scanner to support sql scan, the value can be int64, float64, []byte or string.
+func (d *Decimal) Scan(value interface{}) error {
+	var str string
+	switch val := value.(type) {
+	case nil:
+		return nil
+	case int64:
+		*d = NewDecimal(val, 0)
+		return nil
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return errScanDecimal
+		}
+		*d = NewDecimalFromFloat(val)
+		return nil
+	case []byte:
+		str = string(val)
+	case string:
+		str = val
+	default:
+		return errScanDecimal
+	}
+	dec, err := ParseDecimal(str)
+	if err != nil {
+		return err
+	}
+	*d = dec
+	return nil
+}
+
+// Value implementations driver.Valuer to support sql value, the decimal value is stored as a string to keep the precision.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
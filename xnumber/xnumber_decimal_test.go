@@ -0,0 +1,281 @@
+package xnumber
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func mustDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, tc := range []struct {
+		give      string
+		want      string
+		wantScale int32
+		wantErr   error
+	}{
+		{"0", "0", 0, nil},
+		{"-0", "0", 0, nil},
+		{"123", "123", 0, nil},
+		{"+123", "123", 0, nil},
+		{"123.450", "123.450", 3, nil},
+		{"-123.45", "-123.45", 2, nil},
+		{".5", "0.5", 1, nil},
+		{"-.5", "-0.5", 1, nil},
+		{"5.", "5", 0, nil},
+		{"0.001", "0.001", 3, nil},
+		{"1.5e3", "1500", 0, nil},
+		{"1.5E-3", "0.0015", 4, nil},
+		{"-12e-1", "-1.2", 1, nil},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9, nil},
+		{"", "0", 0, errInvalidDecimal},
+		{"-", "0", 0, errInvalidDecimal},
+		{".", "0", 0, errInvalidDecimal},
+		{"e5", "0", 0, errInvalidDecimal},
+		{"1e", "0", 0, errInvalidDecimal},
+		{"1e99999", "0", 0, errInvalidDecimal},
+		{"1.2.3", "0", 0, errInvalidDecimal},
+		{"1,000", "0", 0, errInvalidDecimal},
+		{" 1", "0", 0, errInvalidDecimal},
+		{"--1", "0", 0, errInvalidDecimal},
+	} {
+		d, err := ParseDecimal(tc.give)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, d.String(), tc.want)
+		xtesting.Equal(t, d.Scale(), tc.wantScale)
+	}
+
+	xtesting.Equal(t, ParseDecimalOr("1.5", Decimal{}).String(), "1.5")
+	xtesting.Equal(t, ParseDecimalOr("x", NewDecimal(1, 1)).String(), "0.1")
+}
+
+func TestNewDecimal(t *testing.T) {
+	xtesting.Equal(t, Decimal{}.String(), "0")
+	xtesting.True(t, Decimal{}.IsZero())
+	xtesting.Equal(t, NewDecimal(12345, 2).String(), "123.45")
+	xtesting.Equal(t, NewDecimal(-5, 3).String(), "-0.005")
+	xtesting.Equal(t, NewDecimal(0, 2).String(), "0.00")
+	xtesting.Equal(t, NewDecimal(math.MinInt64, 0).String(), "-9223372036854775808")
+	xtesting.Equal(t, NewDecimal(12345, 2).Unscaled(), big.NewInt(12345))
+	xtesting.PanicWithValue(t, panicDecimalNegativeScale, func() { NewDecimal(1, -1) })
+
+	xtesting.Equal(t, NewDecimalFromFloat(0.1).String(), "0.1")
+	xtesting.Equal(t, NewDecimalFromFloat(-2.675).String(), "-2.675")
+	xtesting.Equal(t, NewDecimalFromFloat(1e21).String(), "1000000000000000000000")
+	xtesting.Equal(t, NewDecimalFromFloat(0).String(), "0")
+	xtesting.PanicWithValue(t, panicDecimalNaNOrInf, func() { NewDecimalFromFloat(math.NaN()) })
+	xtesting.PanicWithValue(t, panicDecimalNaNOrInf, func() { NewDecimalFromFloat(math.Inf(1)) })
+
+	xtesting.Equal(t, NewDecimal(-15, 1).Float64(), -1.5)
+	xtesting.Equal(t, Decimal{}.Float64(), 0.0)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	for _, tc := range []struct {
+		give1   string
+		give2   string
+		wantAdd string
+		wantSub string
+		wantMul string
+		wantCmp int
+	}{
+		{"0", "0", "0", "0", "0", 0},
+		{"1.5", "2.25", "3.75", "-0.75", "3.375", -1},
+		{"0.1", "0.2", "0.3", "-0.1", "0.02", -1},
+		{"-1.10", "1.1", "0.00", "-2.20", "-1.210", -1},
+		{"100", "0.01", "100.01", "99.99", "1.00", 1},
+		{"1.0", "1.00", "2.00", "0.00", "1.000", 0},
+		{"-3", "-2", "-5", "-1", "6", -1},
+		{"99999999999999999999", "1", "100000000000000000000", "99999999999999999998", "99999999999999999999", 1},
+	} {
+		d1, d2 := mustDecimal(tc.give1), mustDecimal(tc.give2)
+		xtesting.Equal(t, d1.Add(d2).String(), tc.wantAdd)
+		xtesting.Equal(t, d1.Sub(d2).String(), tc.wantSub)
+		xtesting.Equal(t, d1.Mul(d2).String(), tc.wantMul)
+		xtesting.Equal(t, d1.Cmp(d2), tc.wantCmp)
+		xtesting.Equal(t, d2.Cmp(d1), -tc.wantCmp)
+		xtesting.Equal(t, d1.Equal(d2), tc.wantCmp == 0)
+		xtesting.Equal(t, d1.LessThan(d2), tc.wantCmp < 0)
+		xtesting.Equal(t, d1.GreaterThan(d2), tc.wantCmp > 0)
+	}
+
+	d := mustDecimal("-12.30")
+	xtesting.Equal(t, d.Neg().String(), "12.30")
+	xtesting.Equal(t, d.Abs().String(), "12.30")
+	xtesting.Equal(t, d.Sign(), -1)
+	xtesting.Equal(t, d.String(), "-12.30") // immutable
+	xtesting.Equal(t, Decimal{}.Add(NewDecimal(5, 1)).String(), "0.5")
+	xtesting.Equal(t, mustDecimal("19.99").MulRound(NewDecimal(7, 2), 2, RoundHalfUp).String(), "1.40")
+}
+
+func TestDecimalRoundAndDiv(t *testing.T) {
+	for _, tc := range []struct {
+		give      string
+		giveScale int32
+		giveMode  RoundingMode
+		want      string
+	}{
+		{"12.345", 2, RoundHalfUp, "12.35"},
+		{"12.345", 2, RoundHalfDown, "12.34"},
+		{"12.345", 2, RoundHalfEven, "12.34"},
+		{"12.355", 2, RoundHalfEven, "12.36"},
+		{"12.3451", 2, RoundHalfDown, "12.35"},
+		{"-12.345", 2, RoundHalfUp, "-12.35"},
+		{"-12.345", 2, RoundHalfEven, "-12.34"},
+		{"12.341", 2, RoundUp, "12.35"},
+		{"-12.341", 2, RoundUp, "-12.35"},
+		{"12.349", 2, RoundDown, "12.34"},
+		{"-12.349", 2, RoundDown, "-12.34"},
+		{"12.341", 2, RoundCeiling, "12.35"},
+		{"-12.349", 2, RoundCeiling, "-12.34"},
+		{"12.349", 2, RoundFloor, "12.34"},
+		{"-12.341", 2, RoundFloor, "-12.35"},
+		{"-0.004", 2, RoundHalfUp, "0.00"},
+		{"9.995", 2, RoundHalfUp, "10.00"},
+		{"0.5", 3, RoundHalfUp, "0.500"},
+		{"2.5", 0, RoundBankers, "2"},
+		{"12.30", 2, RoundDown, "12.30"},
+	} {
+		xtesting.Equal(t, mustDecimal(tc.give).Round(tc.giveScale, tc.giveMode).String(), tc.want)
+	}
+	xtesting.PanicWithValue(t, panicDecimalNegativeScale, func() { NewDecimal(1, 0).Round(-1, RoundHalfUp) })
+
+	for _, tc := range []struct {
+		give1     string
+		give2     string
+		giveScale int32
+		giveMode  RoundingMode
+		want      string
+	}{
+		{"10", "3", 2, RoundHalfUp, "3.33"},
+		{"20", "3", 2, RoundHalfUp, "6.67"},
+		{"2", "3", 2, RoundDown, "0.66"},
+		{"-2", "3", 2, RoundFloor, "-0.67"},
+		{"1", "8", 2, RoundHalfEven, "0.12"},
+		{"3", "8", 2, RoundHalfEven, "0.38"},
+		{"1", "-8", 2, RoundHalfUp, "-0.13"},
+		{"1.5", "0.25", 0, RoundHalfUp, "6"},
+		{"1.5", "0.25", 3, RoundHalfUp, "6.000"},
+		{"0.001", "1000", 2, RoundHalfUp, "0.00"},
+		{"0.001", "1000", 6, RoundHalfUp, "0.000001"},
+		{"123.456", "0.001", 0, RoundHalfUp, "123456"},
+		{"0", "7", 2, RoundHalfUp, "0.00"},
+	} {
+		xtesting.Equal(t, mustDecimal(tc.give1).Div(mustDecimal(tc.give2), tc.giveScale, tc.giveMode).String(), tc.want)
+	}
+	xtesting.PanicWithValue(t, panicDecimalDivisionByZero, func() { NewDecimal(1, 0).Div(Decimal{}, 2, RoundHalfUp) })
+	xtesting.PanicWithValue(t, panicDecimalNegativeScale, func() { NewDecimal(1, 0).Div(NewDecimal(1, 0), -1, RoundHalfUp) })
+}
+
+func TestDecimalAllocate(t *testing.T) {
+	for _, tc := range []struct {
+		give       string
+		giveRatios []int64
+		want       []string
+	}{
+		{"0.05", []int64{3, 7}, []string{"0.02", "0.03"}},
+		{"1.00", []int64{1, 1, 1}, []string{"0.34", "0.33", "0.33"}},
+		{"-1.00", []int64{1, 1, 1}, []string{"-0.34", "-0.33", "-0.33"}},
+		{"100", []int64{50, 50}, []string{"50", "50"}},
+		{"0.01", []int64{1, 1}, []string{"0.01", "0.00"}},
+		{"0.03", []int64{0, 1, 1}, []string{"0.00", "0.02", "0.01"}},
+		{"10.00", []int64{1}, []string{"10.00"}},
+		{"0", []int64{1, 2}, []string{"0", "0"}},
+	} {
+		d := mustDecimal(tc.give)
+		parts := d.Allocate(tc.giveRatios)
+		sum := Decimal{}
+		got := make([]string, 0, len(parts))
+		for _, p := range parts {
+			got = append(got, p.String())
+			sum = sum.Add(p)
+		}
+		xtesting.Equal(t, got, tc.want)
+		xtesting.True(t, sum.Equal(d))
+	}
+
+	for _, ratios := range [][]int64{nil, {}, {0, 0}, {1, -1}} {
+		xtesting.PanicWithValue(t, panicDecimalInvalidRatios, func() { NewDecimal(1, 0).Allocate(ratios) })
+	}
+
+	parts := NewDecimal(1000, 2).Split(3)
+	xtesting.Equal(t, len(parts), 3)
+	xtesting.Equal(t, parts[0].String(), "3.34")
+	xtesting.Equal(t, parts[1].String(), "3.33")
+	xtesting.Equal(t, parts[2].String(), "3.33")
+	xtesting.PanicWithValue(t, panicDecimalInvalidRatios, func() { NewDecimal(1, 0).Split(0) })
+}
+
+func TestDecimalMarshal(t *testing.T) {
+	type S struct {
+		Price Decimal  `json:"price"`
+		Tax   *Decimal `json:"tax"`
+	}
+	bs, err := json.Marshal(&S{Price: NewDecimal(12340, 2)})
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, string(bs), `{"price":"123.40","tax":null}`)
+
+	for _, tc := range []struct {
+		give    string
+		want    string
+		wantErr bool
+	}{
+		{`{"price":"123.40"}`, "123.40", false},
+		{`{"price":0.1}`, "0.1", false},
+		{`{"price":-1e-2}`, "-0.01", false},
+		{`{"price":null}`, "0", false},
+		{`{"price":"abc"}`, "0", true},
+		{`{"price":""}`, "0", true},
+		{`{"price":true}`, "0", true},
+	} {
+		s := &S{}
+		err := json.Unmarshal([]byte(tc.give), s)
+		xtesting.Equal(t, err != nil, tc.wantErr)
+		xtesting.Equal(t, s.Price.String(), tc.want)
+	}
+
+	bs, err = NewDecimal(-5, 1).MarshalText()
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, string(bs), "-0.5")
+	d := Decimal{}
+	xtesting.Nil(t, d.UnmarshalText([]byte("1.25")))
+	xtesting.Equal(t, d.String(), "1.25")
+	xtesting.Equal(t, d.UnmarshalText([]byte("x")), errInvalidDecimal)
+	xtesting.Equal(t, d.String(), "1.25")
+}
+
+func TestDecimalScanValue(t *testing.T) {
+	for _, tc := range []struct {
+		give    interface{}
+		want    string
+		wantErr error
+	}{
+		{nil, "7", nil},
+		{int64(-12), "-12", nil},
+		{1.25, "1.25", nil},
+		{[]byte("123.450"), "123.450", nil},
+		{"0.01", "0.01", nil},
+		{"x", "7", errInvalidDecimal},
+		{[]byte("x"), "7", errInvalidDecimal},
+		{math.NaN(), "7", errScanDecimal},
+		{true, "7", errScanDecimal},
+	} {
+		d := NewDecimal(7, 0)
+		xtesting.Equal(t, d.Scan(tc.give), tc.wantErr)
+		xtesting.Equal(t, d.String(), tc.want)
+	}
+
+	v, err := NewDecimal(12340, 2).Value()
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, v, driver.Value("123.40"))
+}
@@ -0,0 +1,399 @@
+package xnumber
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode represents the rounding mode used in Round, FormatFixed and other formatting functions.
+type RoundingMode uint8
+
+const (
+	// RoundHalfUp means rounding to nearest, and ties away from zero, such as 2.5 => 3 and -2.5 => -3.
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfDown means rounding to nearest, and ties toward zero, such as 2.5 => 2 and -2.5 => -2.
+	RoundHalfDown
+
+	// RoundHalfEven means rounding to nearest, and ties to even (banker's rounding), such as 2.5 => 2 and 3.5 => 4.
+	RoundHalfEven
+
+	// RoundUp means rounding away from zero, such as 2.1 => 3 and -2.1 => -3.
+	RoundUp
+
+	// RoundDown means rounding toward zero (truncation), such as 2.9 => 2 and -2.9 => -2.
+	RoundDown
+
+	// RoundCeiling means rounding toward positive infinity, such as 2.1 => 3 and -2.9 => -2.
+	RoundCeiling
+
+	// RoundFloor means rounding toward negative infinity, such as 2.9 => 2 and -2.1 => -3.
+	RoundFloor
+
+	// RoundBankers is the alias of RoundHalfEven.
+	RoundBankers = RoundHalfEven
+)
+
+// floatToDecimal converts given finite float to its shortest decimal string without sign and exponent, such as "123.456".
+func floatToDecimal(f float64) (string, bool) {
+	return strconv.FormatFloat(math.Abs(f), 'f', -1, 64), math.Signbit(f)
+}
+
+// roundDecimal rounds given decimal string without sign (such as "123.456") to given places (can be negative, which means
+// rounding to tens, hundreds and so on) using given RoundingMode, and returns a decimal string with exactly max(places, 0)
+// digits after the decimal point.
+func roundDecimal(s string, negative bool, places int, mode RoundingMode) string {
+	intPart, fracPart := s, ""
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	digits, point := intPart+fracPart, len(intPart)
+	keep := point + places
+	if keep < 0 {
+		digits = strings.Repeat("0", -keep) + digits
+		point, keep = point-keep, 0
+	}
+	if keep > len(digits) {
+		digits += strings.Repeat("0", keep-len(digits))
+	}
+	kept, rest := []byte(digits[:keep]), digits[keep:]
+
+	restNonZero := strings.Trim(rest, "0") != ""
+	first, afterFirstNonZero := byte('0'), false
+	if rest != "" {
+		first, afterFirstNonZero = rest[0], strings.Trim(rest[1:], "0") != ""
+	}
+	increment := false
+	switch mode {
+	case RoundHalfUp:
+		increment = first >= '5'
+	case RoundHalfDown:
+		increment = first > '5' || (first == '5' && afterFirstNonZero)
+	case RoundHalfEven:
+		lastOdd := len(kept) > 0 && (kept[len(kept)-1]-'0')%2 == 1
+		increment = first > '5' || (first == '5' && (afterFirstNonZero || lastOdd))
+	case RoundUp:
+		increment = restNonZero
+	case RoundDown:
+		increment = false
+	case RoundCeiling:
+		increment = restNonZero && !negative
+	case RoundFloor:
+		increment = restNonZero && negative
+	}
+	if increment {
+		i := len(kept) - 1
+		for ; i >= 0 && kept[i] == '9'; i-- {
+			kept[i] = '0'
+		}
+		if i >= 0 {
+			kept[i]++
+		} else {
+			kept = append([]byte{'1'}, kept...)
+			point++
+		}
+	}
+
+	var result string
+	if places >= 0 {
+		result = strings.TrimLeft(string(kept[:point]), "0")
+		if result == "" {
+			result = "0"
+		}
+		if places > 0 {
+			result += "." + string(kept[point:])
+		}
+	} else {
+		result = strings.TrimLeft(string(kept)+strings.Repeat("0", -places), "0")
+		if result == "" {
+			result = "0"
+		}
+	}
+	if negative && strings.Trim(result, "0.") != "" {
+		result = "-" + result
+	}
+	return result
+}
+
+// Round rounds given float to given decimal places (can be negative) using given RoundingMode. Note that the rounding is
+// performed on the shortest decimal representation of the float, so Round(2.675, 2, RoundHalfUp) returns 2.68 rather than
+// 2.67.
+//
+// Example:
+// 	Round(2.675, 2, RoundHalfUp)   // => 2.68
+// 	Round(2.5, 0, RoundHalfEven)   // => 2
+// 	Round(1234.5, -2, RoundHalfUp) // => 1200
+func Round(f float64, places int, mode RoundingMode) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	s, negative := floatToDecimal(f)
+	r, _ := strconv.ParseFloat(roundDecimal(s, negative, places, mode), 64)
+	return r
+}
+
+// FormatFixed formats given float with exactly given decimal places using given RoundingMode, this is different from
+// strconv.FormatFloat which always uses RoundHalfEven on binary representation.
+//
+// Example:
+// 	FormatFixed(2.675, 2, RoundHalfUp) // => 2.68
+// 	FormatFixed(2, 2, RoundHalfUp)     // => 2.00
+func FormatFixed(f float64, places int, mode RoundingMode) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s, negative := floatToDecimal(f)
+	return roundDecimal(s, negative, places, mode)
+}
+
+// groupThousands formats given decimal string by grouping the integer part by thousands separator and replacing the
+// decimal point by decimal separator.
+func groupThousands(s, thousandsSep, decimalSep string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart := s, ""
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	sb := strings.Builder{}
+	sb.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(thousandsSep)
+		}
+		sb.WriteRune(c)
+	}
+	if fracPart != "" {
+		sb.WriteString(decimalSep)
+		sb.WriteString(fracPart)
+	}
+	return sb.String()
+}
+
+// FormatThousands formats given float using the shortest decimal representation with thousands separator and decimal
+// separator, see NumberLocale.Format for rounding to fixed places.
+//
+// Example:
+// 	FormatThousands(1234567.891, ",", ".") // => 1,234,567.891
+// 	FormatThousands(-1234.5, ".", ",")     // => -1.234,5
+func FormatThousands(f float64, thousandsSep, decimalSep string) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s, negative := floatToDecimal(f)
+	if negative && s != "0" {
+		s = "-" + s
+	}
+	return groupThousands(s, thousandsSep, decimalSep)
+}
+
+// trimFractionZeros trims the trailing zeros after the decimal point, such as "1.50" => "1.5" and "2.00" => "2".
+func trimFractionZeros(s string) string {
+	if strings.IndexByte(s, '.') == -1 {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// CompactUnit represents a unit used in compact notation, such as {1e3, "K"} and {1e4, "万"}.
+type CompactUnit struct {
+	Value  float64
+	Suffix string
+}
+
+var (
+	// CompactEnglish represents the English compact units, that is K (thousand), M (million), B (billion) and T (trillion).
+	CompactEnglish = []CompactUnit{{1e3, "K"}, {1e6, "M"}, {1e9, "B"}, {1e12, "T"}}
+
+	// CompactChinese represents the Chinese compact units, that is 万 (10^4), 亿 (10^8) and 万亿 (10^12).
+	CompactChinese = []CompactUnit{{1e4, "万"}, {1e8, "亿"}, {1e12, "万亿"}}
+
+	// CompactJapanese represents the Japanese compact units, that is 万 (10^4), 億 (10^8) and 兆 (10^12).
+	CompactJapanese = []CompactUnit{{1e4, "万"}, {1e8, "億"}, {1e12, "兆"}}
+)
+
+// formatCompact is the implementation of FormatCompact and NumberLocale.FormatCompact.
+func formatCompact(f float64, precision int, units []CompactUnit, decimalSep string) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if precision < 0 {
+		precision = 0
+	}
+	abs := math.Abs(f)
+	idx := -1
+	for i, u := range units {
+		if abs >= u.Value {
+			idx = i
+		}
+	}
+	for {
+		value, suffix := f, ""
+		if idx >= 0 {
+			value, suffix = f/units[idx].Value, units[idx].Suffix
+		}
+		s := trimFractionZeros(FormatFixed(value, precision, RoundHalfUp))
+		if idx+1 < len(units) {
+			rounded, _ := strconv.ParseFloat(s, 64)
+			scale := 1.0
+			if idx >= 0 {
+				scale = units[idx].Value
+			}
+			if math.Abs(rounded)*scale >= units[idx+1].Value {
+				idx++ // such as 999.95K => 1M
+				continue
+			}
+		}
+		return strings.Replace(s, ".", decimalSep, 1) + suffix
+	}
+}
+
+// FormatCompact formats given float to compact notation using given units (such as CompactEnglish and CompactChinese), with
+// at most given digits after the decimal point.
+//
+// Example:
+// 	FormatCompact(1234, 1, CompactEnglish)      // => 1.2K
+// 	FormatCompact(3400000, 1, CompactEnglish)   // => 3.4M
+// 	FormatCompact(999999, 1, CompactEnglish)    // => 1M
+// 	FormatCompact(12345, 1, CompactChinese)     // => 1.2万
+// 	FormatCompact(123456789, 2, CompactChinese) // => 1.23亿
+func FormatCompact(f float64, precision int, units []CompactUnit) string {
+	return formatCompact(f, precision, units, ".")
+}
+
+// percentDecimal returns the decimal string of f*100 without floating point multiplication error.
+func percentDecimal(f float64) (string, bool) {
+	s, negative := floatToDecimal(f)
+	intPart, fracPart := s, ""
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+	intPart += fracPart[:2]
+	if fracPart = fracPart[2:]; fracPart != "" {
+		return intPart + "." + fracPart, negative
+	}
+	return intPart, negative
+}
+
+// FormatPercent formats given ratio as percentage with exactly given decimal places using RoundHalfUp.
+//
+// Example:
+// 	FormatPercent(0.1234, 1) // => 12.3%
+// 	FormatPercent(0.5, 0)    // => 50%
+// 	FormatPercent(1.005, 1)  // => 100.5%
+func FormatPercent(f float64, places int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64) + "%"
+	}
+	s, negative := percentDecimal(f)
+	return roundDecimal(s, negative, places, RoundHalfUp) + "%"
+}
+
+// significantPlaces returns the decimal places used to keep given significant digits of f.
+func significantPlaces(f float64, digits int) int {
+	return digits - 1 - int(math.Floor(math.Log10(math.Abs(f))))
+}
+
+// FormatSignificant formats given float with given significant digits using RoundHalfUp, and the exponent notation is
+// never used.
+//
+// Example:
+// 	FormatSignificant(1234.5678, 3)  // => 1230
+// 	FormatSignificant(0.00012345, 2) // => 0.00012
+// 	FormatSignificant(9.996, 3)      // => 10.0
+func FormatSignificant(f float64, digits int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if digits <= 0 {
+		digits = 1
+	}
+	if f == 0 {
+		if digits == 1 {
+			return "0"
+		}
+		return "0." + strings.Repeat("0", digits-1)
+	}
+	s, negative := floatToDecimal(f)
+	places := significantPlaces(f, digits)
+	result := roundDecimal(s, negative, places, RoundHalfUp)
+	if r, _ := strconv.ParseFloat(result, 64); significantPlaces(r, digits) < places {
+		result = roundDecimal(s, negative, places-1, RoundHalfUp) // such as 9.996 => 10.00 => 10.0
+	}
+	return result
+}
+
+// RoundSignificant rounds given float to given significant digits using RoundHalfUp.
+//
+// Example:
+// 	RoundSignificant(1234.5678, 3) // => 1230
+// 	RoundSignificant(0.012345, 2)  // => 0.012
+func RoundSignificant(f float64, digits int) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f == 0 {
+		return f
+	}
+	r, _ := strconv.ParseFloat(FormatSignificant(f, digits), 64)
+	return r
+}
+
+// NumberLocale represents the locale-specific number formatting settings, which contains thousands separator, decimal
+// separator and compact units.
+type NumberLocale struct {
+	ThousandsSep string
+	DecimalSep   string
+	CompactUnits []CompactUnit
+}
+
+var (
+	// LocaleEnglish represents the English number locale, such as 1,234.5 and 1.2K.
+	LocaleEnglish = &NumberLocale{ThousandsSep: ",", DecimalSep: ".", CompactUnits: CompactEnglish}
+
+	// LocaleGerman represents the German number locale, such as 1.234,5 and 1,2K.
+	LocaleGerman = &NumberLocale{ThousandsSep: ".", DecimalSep: ",", CompactUnits: CompactEnglish}
+
+	// LocaleFrench represents the French number locale, such as 1 234,5 (using narrow no-break space) and 1,2K.
+	LocaleFrench = &NumberLocale{ThousandsSep: "\u202f", DecimalSep: ",", CompactUnits: CompactEnglish}
+
+	// LocaleChinese represents the Chinese number locale, such as 1,234.5 and 1.2万.
+	LocaleChinese = &NumberLocale{ThousandsSep: ",", DecimalSep: ".", CompactUnits: CompactChinese}
+
+	// LocaleJapanese represents the Japanese number locale, such as 1,234.5 and 1.2万.
+	LocaleJapanese = &NumberLocale{ThousandsSep: ",", DecimalSep: ".", CompactUnits: CompactJapanese}
+)
+
+// Format formats given float with thousands separator and decimal separator, with exactly given decimal places using given
+// RoundingMode, here -1 places means using the shortest decimal representation.
+//
+// Example:
+// 	LocaleEnglish.Format(1234567.891, 2, RoundHalfUp) // => 1,234,567.89
+// 	LocaleGerman.Format(1234567.891, 1, RoundHalfUp)  // => 1.234.567,9
+func (l *NumberLocale) Format(f float64, places int, mode RoundingMode) string {
+	if places < 0 {
+		return FormatThousands(f, l.ThousandsSep, l.DecimalSep)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return groupThousands(FormatFixed(f, places, mode), l.ThousandsSep, l.DecimalSep)
+}
+
+// FormatCompact formats given float to compact notation using the locale's compact units and decimal separator, see
+// FormatCompact for details.
+func (l *NumberLocale) FormatCompact(f float64, precision int) string {
+	return formatCompact(f, precision, l.CompactUnits, l.DecimalSep)
+}
+
+// FormatPercent formats given ratio as percentage using the locale's separators, see FormatPercent for details.
+func (l *NumberLocale) FormatPercent(f float64, places int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return FormatPercent(f, places)
+	}
+	s, negative := percentDecimal(f)
+	return groupThousands(roundDecimal(s, negative, places, RoundHalfUp), l.ThousandsSep, l.DecimalSep) + "%"
+}
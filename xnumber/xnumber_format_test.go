@@ -0,0 +1,220 @@
+package xnumber
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	for _, tc := range []struct {
+		give       float64
+		givePlaces int
+		giveMode   RoundingMode
+		want       float64
+	}{
+		{0, 2, RoundHalfUp, 0},
+		{2.675, 2, RoundHalfUp, 2.68},
+		{2.5, 0, RoundHalfUp, 3},
+		{-2.5, 0, RoundHalfUp, -3},
+		{2.5, 0, RoundHalfDown, 2},
+		{-2.5, 0, RoundHalfDown, -2},
+		{2.51, 0, RoundHalfDown, 3},
+		{2.5, 0, RoundHalfEven, 2},
+		{3.5, 0, RoundHalfEven, 4},
+		{-2.5, 0, RoundBankers, -2},
+		{2.500001, 0, RoundHalfEven, 3},
+		{0.125, 2, RoundHalfEven, 0.12},
+		{0.135, 2, RoundHalfEven, 0.14},
+		{2.1, 0, RoundUp, 3},
+		{-2.1, 0, RoundUp, -3},
+		{2.9, 0, RoundDown, 2},
+		{-2.9, 0, RoundDown, -2},
+		{2.1, 0, RoundCeiling, 3},
+		{-2.9, 0, RoundCeiling, -2},
+		{2.9, 0, RoundFloor, 2},
+		{-2.1, 0, RoundFloor, -3},
+		{9.999, 2, RoundHalfUp, 10},
+		{1234.5, -2, RoundHalfUp, 1200},
+		{1250, -2, RoundHalfEven, 1200},
+		{1350, -2, RoundHalfEven, 1400},
+		{49, -2, RoundHalfUp, 0},
+		{50, -2, RoundHalfUp, 100},
+		{1, -3, RoundUp, 1000},
+		{1e-10, 3, RoundHalfUp, 0},
+		{1e-10, 3, RoundCeiling, 0.001},
+		{1.5e20, 0, RoundHalfUp, 1.5e20},
+	} {
+		xtesting.Equal(t, Round(tc.give, tc.givePlaces, tc.giveMode), tc.want)
+	}
+
+	xtesting.True(t, math.IsNaN(Round(math.NaN(), 2, RoundHalfUp)))
+	xtesting.Equal(t, Round(math.Inf(1), 2, RoundHalfUp), math.Inf(1))
+}
+
+func TestFormatFixed(t *testing.T) {
+	for _, tc := range []struct {
+		give       float64
+		givePlaces int
+		giveMode   RoundingMode
+		want       string
+	}{
+		{0, 0, RoundHalfUp, "0"},
+		{0, 2, RoundHalfUp, "0.00"},
+		{2, 2, RoundHalfUp, "2.00"},
+		{2.675, 2, RoundHalfUp, "2.68"},
+		{2.665, 2, RoundHalfEven, "2.66"},
+		{-2.675, 2, RoundHalfUp, "-2.68"},
+		{-0.001, 2, RoundHalfUp, "0.00"},
+		{-0.001, 2, RoundFloor, "-0.01"},
+		{0.999, 2, RoundHalfUp, "1.00"},
+		{99.95, 1, RoundHalfUp, "100.0"},
+		{1234.5, -2, RoundHalfUp, "1200"},
+		{123456789.123, 1, RoundDown, "123456789.1"},
+		{math.NaN(), 2, RoundHalfUp, "NaN"},
+		{math.Inf(-1), 2, RoundHalfUp, "-Inf"},
+	} {
+		xtesting.Equal(t, FormatFixed(tc.give, tc.givePlaces, tc.giveMode), tc.want)
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	for _, tc := range []struct {
+		give          float64
+		giveThousands string
+		giveDecimal   string
+		want          string
+	}{
+		{0, ",", ".", "0"},
+		{math.Copysign(0, -1), ",", ".", "0"},
+		{12, ",", ".", "12"},
+		{123, ",", ".", "123"},
+		{1234, ",", ".", "1,234"},
+		{123456, ",", ".", "123,456"},
+		{1234567.891, ",", ".", "1,234,567.891"},
+		{-1234567.891, ",", ".", "-1,234,567.891"},
+		{-1234.5, ".", ",", "-1.234,5"},
+		{1234.5, "", ".", "1234.5"},
+		{0.000123, ",", ".", "0.000123"},
+		{1e21, ",", ".", "1,000,000,000,000,000,000,000"},
+		{math.Inf(1), ",", ".", "+Inf"},
+	} {
+		xtesting.Equal(t, FormatThousands(tc.give, tc.giveThousands, tc.giveDecimal), tc.want)
+	}
+}
+
+func TestFormatCompact(t *testing.T) {
+	for _, tc := range []struct {
+		give          float64
+		givePrecision int
+		giveUnits     []CompactUnit
+		want          string
+	}{
+		{0, 1, CompactEnglish, "0"},
+		{999, 1, CompactEnglish, "999"},
+		{999.96, 1, CompactEnglish, "1K"},
+		{1000, 1, CompactEnglish, "1K"},
+		{1234, 1, CompactEnglish, "1.2K"},
+		{1250, 1, CompactEnglish, "1.3K"},
+		{-1234, 1, CompactEnglish, "-1.2K"},
+		{3400000, 1, CompactEnglish, "3.4M"},
+		{999999, 1, CompactEnglish, "1M"},
+		{999949, 1, CompactEnglish, "999.9K"},
+		{1234567, 2, CompactEnglish, "1.23M"},
+		{1234567, 0, CompactEnglish, "1M"},
+		{1234567, -1, CompactEnglish, "1M"},
+		{2.5e9, 1, CompactEnglish, "2.5B"},
+		{1.5e15, 1, CompactEnglish, "1500T"},
+		{1234, 1, CompactChinese, "1234"},
+		{12345, 1, CompactChinese, "1.2万"},
+		{123456789, 2, CompactChinese, "1.23亿"},
+		{99999999, 1, CompactChinese, "1亿"},
+		{2e12, 1, CompactChinese, "2万亿"},
+		{3e12, 1, CompactJapanese, "3兆"},
+		{1234, 1, nil, "1234"},
+		{math.NaN(), 1, CompactEnglish, "NaN"},
+	} {
+		xtesting.Equal(t, FormatCompact(tc.give, tc.givePrecision, tc.giveUnits), tc.want)
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	for _, tc := range []struct {
+		give       float64
+		givePlaces int
+		want       string
+	}{
+		{0, 0, "0%"},
+		{0, 1, "0.0%"},
+		{0.5, 0, "50%"},
+		{1, 0, "100%"},
+		{0.1234, 1, "12.3%"},
+		{0.1235, 1, "12.4%"},
+		{0.001, 2, "0.10%"},
+		{0.00005, 2, "0.01%"},
+		{1.005, 1, "100.5%"},
+		{0.0115, 1, "1.2%"},
+		{-0.25, 0, "-25%"},
+		{12.5, 0, "1250%"},
+		{math.Inf(1), 0, "+Inf%"},
+	} {
+		xtesting.Equal(t, FormatPercent(tc.give, tc.givePlaces), tc.want)
+	}
+}
+
+func TestFormatSignificant(t *testing.T) {
+	for _, tc := range []struct {
+		give       float64
+		giveDigits int
+		want       string
+	}{
+		{0, 1, "0"},
+		{0, 3, "0.00"},
+		{1234.5678, 3, "1230"},
+		{1234.5678, 6, "1234.57"},
+		{1234.5678, 10, "1234.567800"},
+		{0.00012345, 2, "0.00012"},
+		{-0.00012355, 3, "-0.000124"},
+		{9.996, 3, "10.0"},
+		{99.96, 3, "100"},
+		{0.9999, 2, "1.0"},
+		{5, 0, "5"},
+		{123456, 1, "100000"},
+		{math.NaN(), 1, "NaN"},
+	} {
+		xtesting.Equal(t, FormatSignificant(tc.give, tc.giveDigits), tc.want)
+	}
+
+	xtesting.Equal(t, RoundSignificant(1234.5678, 3), 1230.0)
+	xtesting.Equal(t, RoundSignificant(0.012345, 2), 0.012)
+	xtesting.Equal(t, RoundSignificant(0, 2), 0.0)
+}
+
+func TestNumberLocale(t *testing.T) {
+	for _, tc := range []struct {
+		giveLocale *NumberLocale
+		give       float64
+		givePlaces int
+		want       string
+	}{
+		{LocaleEnglish, 1234567.891, -1, "1,234,567.891"},
+		{LocaleEnglish, 1234567.891, 2, "1,234,567.89"},
+		{LocaleEnglish, -1234567.5, 0, "-1,234,568"},
+		{LocaleGerman, 1234567.891, 1, "1.234.567,9"},
+		{LocaleFrench, 1234567.891, 2, "1 234 567,89"},
+		{LocaleChinese, 1234.5, 2, "1,234.50"},
+		{LocaleEnglish, math.NaN(), 2, "NaN"},
+	} {
+		xtesting.Equal(t, tc.giveLocale.Format(tc.give, tc.givePlaces, RoundHalfUp), tc.want)
+	}
+	xtesting.Equal(t, LocaleEnglish.Format(2.5, 0, RoundHalfEven), "2")
+
+	xtesting.Equal(t, LocaleEnglish.FormatCompact(1234, 1), "1.2K")
+	xtesting.Equal(t, LocaleGerman.FormatCompact(1234, 1), "1,2K")
+	xtesting.Equal(t, LocaleChinese.FormatCompact(12345, 1), "1.2万")
+	xtesting.Equal(t, LocaleJapanese.FormatCompact(123456789, 1), "1.2億")
+
+	xtesting.Equal(t, LocaleEnglish.FormatPercent(12.345, 1), "1,234.5%")
+	xtesting.Equal(t, LocaleGerman.FormatPercent(0.1234, 1), "12,3%")
+	xtesting.Equal(t, LocaleGerman.FormatPercent(math.NaN(), 1), "NaN%")
+}
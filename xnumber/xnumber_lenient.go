@@ -0,0 +1,350 @@
+package xnumber
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errInvalidNumber   = errors.New("xnumber: invalid number string")
+	errFloatOutOfRange = errors.New("xnumber: float value out of range")
+	errInvalidBitSize  = errors.New("xnumber: invalid bit size")
+)
+
+// lenientNumber represents a preprocessed number string, which is used in lenient parsers.
+type lenientNumber struct {
+	negative bool
+	base     int    // 10, 16, 8 or 2
+	digits   string // without surrounding whitespaces, sign, base prefix, digit separators and percent sign
+	percent  bool
+}
+
+// isDigitOfBase checks whether given character is a digit in given base, which must be 2, 8, 10 or 16.
+func isDigitOfBase(c byte, base int) bool {
+	switch base {
+	case 2:
+		return c == '0' || c == '1'
+	case 8:
+		return c >= '0' && c <= '7'
+	case 16:
+		return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+	}
+	return c >= '0' && c <= '9'
+}
+
+// isIntegerBitSize checks whether given bit size can be used in ParseIntLenient and ParseUintLenient.
+func isIntegerBitSize(bitSize int) bool {
+	return bitSize == 0 || bitSize == 8 || bitSize == 16 || bitSize == 32 || bitSize == 64
+}
+
+// isThousandsGrouped checks whether `,` in given decimal string is only used as thousands separator, that is the integer part
+// is grouped by exactly three digits, with a leading group of one to three digits, and the other parts have no `,`.
+func isThousandsGrouped(s string) bool {
+	intPart, rest := s, ""
+	if idx := strings.IndexAny(s, ".eE"); idx != -1 {
+		intPart, rest = s[:idx], s[idx:]
+	}
+	if strings.IndexByte(rest, ',') != -1 {
+		return false
+	}
+	groups := strings.Split(intPart, ",")
+	for i, g := range groups {
+		if (i == 0 && (len(g) < 1 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+			return false
+		}
+		for j := 0; j < len(g); j++ {
+			if g[j] < '0' || g[j] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// prepareLenient preprocesses given string to lenientNumber, it trims whitespaces, parses sign, base prefix and percent sign,
+// and removes digit separators, that is `_` which must be between two digits, and `,` which must be thousands separator of
+// decimal numbers.
+func prepareLenient(s string) (*lenientNumber, error) {
+	s = strings.TrimSpace(s)
+	n := &lenientNumber{base: 10}
+	if strings.HasSuffix(s, "%") {
+		n.percent = true
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		n.negative = s[0] == '-'
+		s = s[1:]
+	}
+	if len(s) > 2 && s[0] == '0' {
+		switch s[1] {
+		case 'x', 'X':
+			n.base = 16
+		case 'o', 'O':
+			n.base = 8
+		case 'b', 'B':
+			n.base = 2
+		}
+		if n.base != 10 {
+			s = s[2:]
+		}
+	}
+	if s == "" || s[0] == '+' || s[0] == '-' || (n.percent && n.base != 10) {
+		return nil, errInvalidNumber
+	}
+
+	if strings.IndexAny(s, "_,") == -1 {
+		n.digits = s
+		return n, nil
+	}
+	if strings.IndexByte(s, ',') != -1 && (n.base != 10 || !isThousandsGrouped(s)) {
+		return nil, errInvalidNumber
+	}
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' || c == ',' {
+			if i == 0 || i == len(s)-1 || !isDigitOfBase(s[i-1], n.base) || !isDigitOfBase(s[i+1], n.base) {
+				return nil, errInvalidNumber
+			}
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	n.digits = sb.String()
+	return n, nil
+}
+
+// magnitude returns the absolute integer value of lenientNumber, scientific notation and percent sign are supported, but the
+// value must be an integer.
+func (n *lenientNumber) magnitude() (uint64, error) {
+	if n.base != 10 {
+		u, err := strconv.ParseUint(n.digits, n.base, 64)
+		if err != nil {
+			if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+				return 0, errIntegerOutOfRange
+			}
+			return 0, errInvalidNumber
+		}
+		return u, nil
+	}
+
+	d, err := ParseDecimal(n.digits)
+	if err != nil {
+		return 0, errInvalidNumber
+	}
+	if n.percent {
+		d.scale += 2
+	}
+	if d.scale > 0 {
+		i := d.Round(0, RoundDown)
+		if !i.Equal(d) {
+			return 0, errInvalidNumber // not an integer
+		}
+		d = i
+	}
+	if v := d.unscaled(); v.IsUint64() {
+		return v.Uint64(), nil
+	}
+	return 0, errIntegerOutOfRange
+}
+
+// float returns the float value of lenientNumber with given bit size, which must be 32 or 64.
+func (n *lenientNumber) float(bitSize int) (float64, error) {
+	var f float64
+	if n.base != 10 {
+		u, err := n.magnitude()
+		if err != nil {
+			return 0, err
+		}
+		f, _ = strconv.ParseFloat(strconv.FormatUint(u, 10), bitSize) // always valid
+	} else {
+		digits := n.digits
+		if n.percent {
+			d, err := ParseDecimal(digits)
+			if err != nil {
+				return 0, errInvalidNumber
+			}
+			d.scale += 2
+			digits = d.String()
+		}
+		var err error
+		f, err = strconv.ParseFloat(digits, bitSize)
+		if err != nil {
+			if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+				return 0, errFloatOutOfRange
+			}
+			return 0, errInvalidNumber
+		}
+	}
+	if n.negative {
+		f = -f
+	}
+	return f, nil
+}
+
+// ParseIntLenient parses a string to int64 leniently with given bit size (0 for int, 8, 16, 32 and 64). It accepts surrounding
+// whitespaces, `0x` `0o` `0b` base prefixes (case-insensitive), digit separators (`_` which must be between two digits, and `,`
+// which must be thousands separator of decimal numbers), scientific notation and percent sign (which means dividing by 100),
+// but the final value must be an integer. Note that a leading "0" does not mean octal, and `,` is never treated as decimal
+// separator.
+//
+// Example:
+// 	ParseIntLenient(" 1_000_000 ", 64) // => 1000000
+// 	ParseIntLenient("1,000,000", 64)   // => 1000000
+// 	ParseIntLenient("-0xFF", 64)       // => -255
+// 	ParseIntLenient("0b1010", 8)       // => 10
+// 	ParseIntLenient("1.5e3", 64)       // => 1500
+// 	ParseIntLenient("200%", 64)        // => 2
+// 	ParseIntLenient("1.5", 64)         // => error
+// 	ParseIntLenient("1,00", 64)        // => error
+// 	ParseIntLenient("128", 8)          // => error
+func ParseIntLenient(s string, bitSize int) (int64, error) {
+	if !isIntegerBitSize(bitSize) {
+		return 0, errInvalidBitSize
+	}
+	if bitSize == 0 {
+		bitSize = IntSize()
+	}
+	n, err := prepareLenient(s)
+	if err != nil {
+		return 0, err
+	}
+	u, err := n.magnitude()
+	if err != nil {
+		return 0, err
+	}
+	cutoff := uint64(1) << uint(bitSize-1)
+	if (!n.negative && u >= cutoff) || (n.negative && u > cutoff) {
+		return 0, errIntegerOutOfRange
+	}
+	if n.negative {
+		return -int64(u), nil // -(1 << 63) is also correct
+	}
+	return int64(u), nil
+}
+
+// ParseUintLenient parses a string to uint64 leniently with given bit size (0 for uint, 8, 16, 32 and 64), see ParseIntLenient
+// for the accepted formats. Note that "-0" is accepted, but other negative numbers are out of range.
+//
+// Example:
+// 	ParseUintLenient("0xFFFF_FFFF", 32) // => 4294967295
+// 	ParseUintLenient("1e6", 64)         // => 1000000
+// 	ParseUintLenient("-1", 64)          // => error
+func ParseUintLenient(s string, bitSize int) (uint64, error) {
+	if !isIntegerBitSize(bitSize) {
+		return 0, errInvalidBitSize
+	}
+	if bitSize == 0 {
+		bitSize = IntSize()
+	}
+	n, err := prepareLenient(s)
+	if err != nil {
+		return 0, err
+	}
+	u, err := n.magnitude()
+	if err != nil {
+		return 0, err
+	}
+	if (n.negative && u != 0) || (bitSize < 64 && u >= uint64(1)<<uint(bitSize)) {
+		return 0, errIntegerOutOfRange
+	}
+	return u, nil
+}
+
+// ParseFloatLenient parses a string to float64 leniently with given bit size (32 and 64). It accepts surrounding whitespaces,
+// digit separators (`_` and `,`, see ParseIntLenient), percent sign (which means dividing by 100), and the integers with `0x`
+// `0o` `0b` base prefixes, besides the formats accepted by strconv.ParseFloat.
+//
+// Example:
+// 	ParseFloatLenient("1,234.5", 64) // => 1234.5
+// 	ParseFloatLenient("12.5 %", 64)  // => 0.125
+// 	ParseFloatLenient("0x10", 64)    // => 16
+// 	ParseFloatLenient("1e400", 64)   // => error
+func ParseFloatLenient(s string, bitSize int) (float64, error) {
+	if bitSize != 32 && bitSize != 64 {
+		return 0, errInvalidBitSize
+	}
+	n, err := prepareLenient(s)
+	if err != nil {
+		return 0, err
+	}
+	return n.float(bitSize)
+}
+
+// NumberFlag represents a flag used for Number, including: NumberInt, NumberUint, NumberFloat.
+type NumberFlag int8
+
+const (
+	NumberInvalid NumberFlag = iota // For the zero value of Number.
+	NumberInt                       // For integers in the range of int64.
+	NumberUint                      // For positive integers larger than MaxInt64 and in the range of uint64.
+	NumberFloat                     // For numbers with decimal point or percent sign, non-integers and out-of-range integers.
+)
+
+// Number represents a number parsed by ParseNumber, which stores the value in the maximum type of int, uint or float, this
+// is similar to xreflect.Smpval.
+type Number struct {
+	i    int64
+	u    uint64
+	f    float64
+	flag NumberFlag
+}
+
+// ParseNumber parses a string leniently to Number, and detects the type automatically, see ParseIntLenient and ParseFloatLenient
+// for the accepted formats. The string will be parsed to NumberInt or NumberUint if it represents an integer (scientific notation
+// is allowed) without decimal point and percent sign, otherwise it will be parsed to NumberFloat.
+//
+// Example:
+// 	ParseNumber("1_000")                // => NumberInt, 1000
+// 	ParseNumber("-0x10")                // => NumberInt, -16
+// 	ParseNumber("1e6")                  // => NumberInt, 1000000
+// 	ParseNumber("18446744073709551615") // => NumberUint, 18446744073709551615
+// 	ParseNumber("1.0")                  // => NumberFloat, 1
+// 	ParseNumber("50%")                  // => NumberFloat, 0.5
+// 	ParseNumber("1e-3")                 // => NumberFloat, 0.001
+func ParseNumber(s string) (*Number, error) {
+	n, err := prepareLenient(s)
+	if err != nil {
+		return nil, err
+	}
+	if !n.percent && (n.base != 10 || strings.IndexByte(n.digits, '.') == -1) {
+		u, err := n.magnitude()
+		switch {
+		case err == nil && n.negative && u <= uint64(1)<<63:
+			return &Number{i: -int64(u), flag: NumberInt}, nil
+		case err == nil && !n.negative && u <= uint64(MaxInt64):
+			return &Number{i: int64(u), flag: NumberInt}, nil
+		case err == nil && !n.negative:
+			return &Number{u: u, flag: NumberUint}, nil
+		case err != nil && n.base != 10:
+			return nil, err
+		}
+	}
+	f, err := n.float(64)
+	if err != nil {
+		return nil, err
+	}
+	return &Number{f: f, flag: NumberFloat}, nil
+}
+
+// Int returns the int64 value from Number.
+func (n *Number) Int() int64 {
+	return n.i
+}
+
+// Uint returns the uint64 value from Number.
+func (n *Number) Uint() uint64 {
+	return n.u
+}
+
+// Float returns the float64 value from Number.
+func (n *Number) Float() float64 {
+	return n.f
+}
+
+// Flag returns the flag from Number.
+func (n *Number) Flag() NumberFlag {
+	return n.flag
+}
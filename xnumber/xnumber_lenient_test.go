@@ -0,0 +1,224 @@
+package xnumber
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math"
+	"testing"
+)
+
+func TestParseIntLenient(t *testing.T) {
+	for _, tc := range []struct {
+		give        string
+		giveBitSize int
+		want        int64
+		wantErr     error
+	}{
+		{"0", 64, 0, nil},
+		{"-0", 64, 0, nil},
+		{"123", 64, 123, nil},
+		{" +123\t", 64, 123, nil},
+		{"010", 64, 10, nil},
+		{"1_000_000", 64, 1000000, nil},
+		{"1,000,000", 64, 1000000, nil},
+		{"-1,000", 64, -1000, nil},
+		{"0xFF", 64, 255, nil},
+		{"-0XfF", 64, -255, nil},
+		{"0xFF_FF", 64, 65535, nil},
+		{"0o17", 64, 15, nil},
+		{"0b1010", 8, 10, nil},
+		{"0b1_0", 8, 2, nil},
+		{"1e6", 64, 1000000, nil},
+		{"1.5e3", 64, 1500, nil},
+		{"-1.5E3", 64, -1500, nil},
+		{"12.0", 64, 12, nil},
+		{"200%", 64, 2, nil},
+		{"1_500 %", 64, 15, nil},
+		{"127", 8, 127, nil},
+		{"-128", 8, -128, nil},
+		{"-0x80", 8, -128, nil},
+		{"9223372036854775807", 64, math.MaxInt64, nil},
+		{"-9223372036854775808", 64, math.MinInt64, nil},
+		{"-9.223372036854775808e18", 64, math.MinInt64, nil},
+		{"128", 8, 0, errIntegerOutOfRange},
+		{"0x80", 8, 0, errIntegerOutOfRange},
+		{"-129", 8, 0, errIntegerOutOfRange},
+		{"32768", 16, 0, errIntegerOutOfRange},
+		{"2147483648", 32, 0, errIntegerOutOfRange},
+		{"9223372036854775808", 64, 0, errIntegerOutOfRange},
+		{"1e19", 64, 0, errIntegerOutOfRange},
+		{"1e30", 64, 0, errIntegerOutOfRange},
+		{"0x1_0000_0000_0000_0000", 64, 0, errIntegerOutOfRange},
+		{"", 64, 0, errInvalidNumber},
+		{" ", 64, 0, errInvalidNumber},
+		{"-", 64, 0, errInvalidNumber},
+		{"%", 64, 0, errInvalidNumber},
+		{"0x", 64, 0, errInvalidNumber},
+		{"0xG", 64, 0, errInvalidNumber},
+		{"0b12", 64, 0, errInvalidNumber},
+		{"0o8", 64, 0, errInvalidNumber},
+		{"--1", 64, 0, errInvalidNumber},
+		{"+-1", 64, 0, errInvalidNumber},
+		{"1.5", 64, 0, errInvalidNumber},
+		{"1e-1", 64, 0, errInvalidNumber},
+		{"50%", 64, 0, errInvalidNumber},
+		{"0x10%", 64, 0, errInvalidNumber},
+		{"_1", 64, 0, errInvalidNumber},
+		{"1_", 64, 0, errInvalidNumber},
+		{"1__0", 64, 0, errInvalidNumber},
+		{"1,,0", 64, 0, errInvalidNumber},
+		{"1,5", 64, 0, errInvalidNumber},
+		{"1,00", 64, 0, errInvalidNumber},
+		{"12,3456", 64, 0, errInvalidNumber},
+		{"1._5", 64, 0, errInvalidNumber},
+		{"1 000", 64, 0, errInvalidNumber},
+		{"abc", 64, 0, errInvalidNumber},
+		{"1", 7, 0, errInvalidBitSize},
+	} {
+		i, err := ParseIntLenient(tc.give, tc.giveBitSize)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, i, tc.want)
+	}
+
+	i, err := ParseIntLenient("1_000", 0)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, i, int64(1000))
+}
+
+func TestParseUintLenient(t *testing.T) {
+	for _, tc := range []struct {
+		give        string
+		giveBitSize int
+		want        uint64
+		wantErr     error
+	}{
+		{"0", 64, 0, nil},
+		{"-0", 64, 0, nil},
+		{" 255 ", 8, 255, nil},
+		{"0xFFFF_FFFF", 32, math.MaxUint32, nil},
+		{"1e6", 64, 1000000, nil},
+		{"300%", 64, 3, nil},
+		{"18,446,744,073,709,551,615", 64, math.MaxUint64, nil},
+		{"0xFFFFFFFFFFFFFFFF", 64, math.MaxUint64, nil},
+		{"256", 8, 0, errIntegerOutOfRange},
+		{"65536", 16, 0, errIntegerOutOfRange},
+		{"0x1_0000_0000", 32, 0, errIntegerOutOfRange},
+		{"18446744073709551616", 64, 0, errIntegerOutOfRange},
+		{"-1", 64, 0, errIntegerOutOfRange},
+		{"-0x1", 64, 0, errIntegerOutOfRange},
+		{"1.5", 64, 0, errInvalidNumber},
+		{"x", 64, 0, errInvalidNumber},
+		{"1", 128, 0, errInvalidBitSize},
+	} {
+		u, err := ParseUintLenient(tc.give, tc.giveBitSize)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, u, tc.want)
+	}
+
+	u, err := ParseUintLenient("1_000", 0)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, u, uint64(1000))
+}
+
+func TestParseFloatLenient(t *testing.T) {
+	for _, tc := range []struct {
+		give        string
+		giveBitSize int
+		want        float64
+		wantErr     error
+	}{
+		{"0", 64, 0, nil},
+		{"1.5", 64, 1.5, nil},
+		{" -1.5 ", 64, -1.5, nil},
+		{".5", 64, 0.5, nil},
+		{"1,234.5", 64, 1234.5, nil},
+		{"1_234.567_8", 64, 1234.5678, nil},
+		{"1e3", 64, 1000, nil},
+		{"-2.5E-3", 64, -0.0025, nil},
+		{"50%", 64, 0.5, nil},
+		{"12.5 %", 64, 0.125, nil},
+		{"-1.1%", 64, -0.011, nil},
+		{"1e2%", 64, 1, nil},
+		{"0x10", 64, 16, nil},
+		{"-0b11", 64, -3, nil},
+		{"0.1", 32, float64(float32(0.1)), nil},
+		{"1e400", 64, 0, errFloatOutOfRange},
+		{"1e39", 32, 0, errFloatOutOfRange},
+		{"", 64, 0, errInvalidNumber},
+		{"1.2.3", 64, 0, errInvalidNumber},
+		{"1,5", 64, 0, errInvalidNumber},
+		{"1,00", 64, 0, errInvalidNumber},
+		{"12,3456", 64, 0, errInvalidNumber},
+		{"1.,5", 64, 0, errInvalidNumber},
+		{"0x1p-2", 64, 0, errInvalidNumber},
+		{"0xZ", 64, 0, errInvalidNumber},
+		{"inf%", 64, 0, errInvalidNumber},
+		{"abc", 64, 0, errInvalidNumber},
+		{"1", 16, 0, errInvalidBitSize},
+	} {
+		f, err := ParseFloatLenient(tc.give, tc.giveBitSize)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, f, tc.want)
+	}
+
+	f, err := ParseFloatLenient("-Inf", 64)
+	xtesting.Nil(t, err)
+	xtesting.True(t, math.IsInf(f, -1))
+	f, err = ParseFloatLenient("NaN", 64)
+	xtesting.Nil(t, err)
+	xtesting.True(t, math.IsNaN(f))
+}
+
+func TestParseNumber(t *testing.T) {
+	for _, tc := range []struct {
+		give      string
+		wantFlag  NumberFlag
+		wantInt   int64
+		wantUint  uint64
+		wantFloat float64
+		wantErr   error
+	}{
+		{"0", NumberInt, 0, 0, 0, nil},
+		{"-0", NumberInt, 0, 0, 0, nil},
+		{" 1_000 ", NumberInt, 1000, 0, 0, nil},
+		{"-1,000", NumberInt, -1000, 0, 0, nil},
+		{"-0x10", NumberInt, -16, 0, 0, nil},
+		{"0b11", NumberInt, 3, 0, 0, nil},
+		{"1e6", NumberInt, 1000000, 0, 0, nil},
+		{"9223372036854775807", NumberInt, math.MaxInt64, 0, 0, nil},
+		{"-9223372036854775808", NumberInt, math.MinInt64, 0, 0, nil},
+		{"9223372036854775808", NumberUint, 0, 1 << 63, 0, nil},
+		{"18446744073709551615", NumberUint, 0, math.MaxUint64, 0, nil},
+		{"0xFFFFFFFFFFFFFFFF", NumberUint, 0, math.MaxUint64, 0, nil},
+		{"18446744073709551616", NumberFloat, 0, 0, 18446744073709551616, nil},
+		{"-9223372036854775809", NumberFloat, 0, 0, -9223372036854775809, nil},
+		{"1e30", NumberFloat, 0, 0, 1e30, nil},
+		{"1.0", NumberFloat, 0, 0, 1, nil},
+		{"-1.5", NumberFloat, 0, 0, -1.5, nil},
+		{"50%", NumberFloat, 0, 0, 0.5, nil},
+		{"200%", NumberFloat, 0, 0, 2, nil},
+		{"1e-3", NumberFloat, 0, 0, 0.001, nil},
+		{"", NumberInvalid, 0, 0, 0, errInvalidNumber},
+		{"abc", NumberInvalid, 0, 0, 0, errInvalidNumber},
+		{"1.2.3", NumberInvalid, 0, 0, 0, errInvalidNumber},
+		{"0x1_0000_0000_0000_0000", NumberInvalid, 0, 0, 0, errIntegerOutOfRange},
+		{"0xG", NumberInvalid, 0, 0, 0, errInvalidNumber},
+		{"1e400", NumberInvalid, 0, 0, 0, errFloatOutOfRange},
+	} {
+		n, err := ParseNumber(tc.give)
+		xtesting.Equal(t, err, tc.wantErr)
+		if err != nil {
+			xtesting.Nil(t, n)
+			continue
+		}
+		xtesting.Equal(t, n.Flag(), tc.wantFlag)
+		xtesting.Equal(t, n.Int(), tc.wantInt)
+		xtesting.Equal(t, n.Uint(), tc.wantUint)
+		xtesting.Equal(t, n.Float(), tc.wantFloat)
+	}
+
+	n, err := ParseNumber("inf")
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, n.Flag(), NumberFloat)
+	xtesting.True(t, math.IsInf(n.Float(), 1))
+	xtesting.Equal(t, (&Number{}).Flag(), NumberInvalid)
+}
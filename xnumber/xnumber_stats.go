@@ -0,0 +1,383 @@
+package xnumber
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// ======================
+// descriptive statistics
+// ======================
+
+// Float64sFromInts converts an int slice to float64 slice, it can be used to compute statistics over integer slices.
+func Float64sFromInts(xs []int) []float64 {
+	out := make([]float64, len(xs))
+	for i, x := range xs {
+		out[i] = float64(x)
+	}
+	return out
+}
+
+// Float64sFromInt64s converts an int64 slice to float64 slice, it can be used to compute statistics over integer slices. Note
+// that the values whose absolute value is larger than 2^53 may lose precision, because float64 has only 53 bits of mantissa.
+func Float64sFromInt64s(xs []int64) []float64 {
+	out := make([]float64, len(xs))
+	for i, x := range xs {
+		out[i] = float64(x)
+	}
+	return out
+}
+
+// Float64sFromUint64s converts an uint64 slice to float64 slice, it can be used to compute statistics over integer slices. Note
+// that the values larger than 2^53 may lose precision, because float64 has only 53 bits of mantissa.
+func Float64sFromUint64s(xs []uint64) []float64 {
+	out := make([]float64, len(xs))
+	for i, x := range xs {
+		out[i] = float64(x)
+	}
+	return out
+}
+
+// Sum returns the sum of given values, returns 0 if the slice is empty.
+func Sum(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of given values, returns NaN if the slice is empty.
+//
+// Example:
+// 	Mean([]float64{1, 2, 3, 4}) // => 2.5
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	return Sum(xs) / float64(len(xs))
+}
+
+// sortedCopy returns a sorted copy of given values, the original slice is not modified.
+func sortedCopy(xs []float64) []float64 {
+	sorted := make([]float64, len(xs))
+	copy(sorted, xs)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// Median returns the median of given values, that is the average of the two middle values for even length, returns NaN if the
+// slice is empty. Note that the original slice is not modified.
+//
+// Example:
+// 	Median([]float64{3, 1, 2})    // => 2
+// 	Median([]float64{4, 1, 3, 2}) // => 2.5
+func Median(xs []float64) float64 {
+	return Percentile(xs, 50)
+}
+
+// Mode returns the most frequent values of given values in ascending order, there will be more than one value if the data is
+// multimodal, and returns empty slice if the slice is empty.
+//
+// Example:
+// 	Mode([]float64{1, 2, 2, 3})    // => [2]
+// 	Mode([]float64{1, 1, 2, 2, 3}) // => [1, 2]
+func Mode(xs []float64) []float64 {
+	counts := make(map[float64]int, len(xs))
+	maxCount := 0
+	for _, x := range xs {
+		counts[x]++
+		if counts[x] > maxCount {
+			maxCount = counts[x]
+		}
+	}
+	modes := make([]float64, 0)
+	for x, c := range counts {
+		if c == maxCount {
+			modes = append(modes, x)
+		}
+	}
+	sort.Float64s(modes)
+	return modes
+}
+
+// variance returns the sum of squared deviations divided by len(xs)-ddof.
+func variance(xs []float64, ddof int) float64 {
+	if len(xs)-ddof <= 0 {
+		return math.NaN()
+	}
+	mean := Mean(xs)
+	sum := 0.0
+	for _, x := range xs {
+		sum += (x - mean) * (x - mean)
+	}
+	return sum / float64(len(xs)-ddof)
+}
+
+// Variance returns the population variance of given values, returns NaN if the slice is empty.
+//
+// Example:
+// 	Variance([]float64{2, 4, 4, 4, 5, 5, 7, 9}) // => 4
+func Variance(xs []float64) float64 {
+	return variance(xs, 0)
+}
+
+// SampleVariance returns the sample variance (with Bessel's correction) of given values, returns NaN if the slice has less
+// than two values.
+//
+// Example:
+// 	SampleVariance([]float64{1, 2, 3, 4}) // => 1.6666666666666667
+func SampleVariance(xs []float64) float64 {
+	return variance(xs, 1)
+}
+
+// StdDev returns the population standard deviation of given values, returns NaN if the slice is empty.
+//
+// Example:
+// 	StdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}) // => 2
+func StdDev(xs []float64) float64 {
+	return math.Sqrt(Variance(xs))
+}
+
+// SampleStdDev returns the sample standard deviation (with Bessel's correction) of given values, returns NaN if the slice has
+// less than two values.
+func SampleStdDev(xs []float64) float64 {
+	return math.Sqrt(SampleVariance(xs))
+}
+
+// percentileSorted returns the p-th percentile of sorted values using linear interpolation between closest ranks.
+func percentileSorted(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 || math.IsNaN(p) || p < 0 || p > 100 {
+		return math.NaN()
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return sorted[lo]
+	}
+	return sorted[lo] + (rank-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// Percentile returns the p-th percentile (p in [0, 100]) of given values using linear interpolation between closest ranks,
+// which is the same as Excel's PERCENTILE.INC and numpy's default method. It returns NaN if the slice is empty or p is out
+// of range. Note that the original slice is not modified.
+//
+// Example:
+// 	Percentile([]float64{1, 2, 3, 4}, 50) // => 2.5
+// 	Percentile([]float64{1, 2, 3, 4}, 90) // => 3.7
+func Percentile(xs []float64, p float64) float64 {
+	return percentileSorted(sortedCopy(xs), p)
+}
+
+// Percentiles returns the percentiles of given values for each p in ps, this is faster than calling Percentile multiple times
+// because the values are only sorted once.
+//
+// Example:
+// 	Percentiles([]float64{1, 2, 3, 4, 5}, 50, 90, 99) // => [3, 4.6, 4.96]
+func Percentiles(xs []float64, ps ...float64) []float64 {
+	sorted := sortedCopy(xs)
+	out := make([]float64, len(ps))
+	for i, p := range ps {
+		out[i] = percentileSorted(sorted, p)
+	}
+	return out
+}
+
+// MinMax returns the minimum and maximum values of given values, returns NaN for both if the slice is empty.
+//
+// Example:
+// 	MinMax([]float64{3, 1, 2}) // => 1, 3
+func MinMax(xs []float64) (min, max float64) {
+	if len(xs) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	min, max = xs[0], xs[0]
+	for _, x := range xs[1:] {
+		if x < min {
+			min = x
+		}
+		if x > max {
+			max = x
+		}
+	}
+	return min, max
+}
+
+// =======
+// Summary
+// =======
+
+const (
+	// summaryDefaultReservoirSize is the default reservoir size of Summary.
+	summaryDefaultReservoirSize = 1024
+)
+
+// Summary represents a streaming statistics accumulator, which is safe for concurrent use. It computes count, sum, min, max,
+// mean and variance exactly using Welford's online algorithm, and estimates percentiles using a uniform reservoir sample
+// (Algorithm R), so the percentiles are exact until the number of values exceeds the reservoir size. Note that the zero value
+// is ready to use with the default reservoir size.
+//
+// Example:
+// 	s := NewSummary(0) // using default reservoir size
+// 	for _, latency := range latencies {
+// 		s.Add(latency)
+// 	}
+// 	s.Mean()         // => mean latency
+// 	s.Percentile(99) // => estimated p99 latency
+type Summary struct {
+	mu        sync.RWMutex
+	count     int64
+	sum       float64
+	mean      float64
+	m2        float64 // sum of squared deviations from the mean
+	min       float64
+	max       float64
+	reservoir []float64
+	size      int
+}
+
+// NewSummary creates an empty Summary with given reservoir size, which is the maximum number of values kept for estimating
+// percentiles, the default size (1024) will be used if size is not positive.
+func NewSummary(size int) *Summary {
+	if size <= 0 {
+		size = summaryDefaultReservoirSize
+	}
+	return &Summary{reservoir: make([]float64, 0, size), size: size}
+}
+
+// Add adds a value to Summary.
+func (s *Summary) Add(x float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size <= 0 {
+		s.size = summaryDefaultReservoirSize // for zero value
+	}
+	s.count++
+	s.sum += x
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+	if s.count == 1 || x < s.min {
+		s.min = x
+	}
+	if s.count == 1 || x > s.max {
+		s.max = x
+	}
+
+	if len(s.reservoir) < s.size {
+		s.reservoir = append(s.reservoir, x)
+	} else if j := FastrandUint64() % uint64(s.count); j < uint64(s.size) {
+		s.reservoir[j] = x
+	}
+}
+
+// AddAll adds all given values to Summary.
+func (s *Summary) AddAll(xs []float64) {
+	for _, x := range xs {
+		s.Add(x)
+	}
+}
+
+// Reset clears all values in Summary.
+func (s *Summary) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count, s.sum, s.mean, s.m2, s.min, s.max = 0, 0, 0, 0, 0, 0
+	s.reservoir = s.reservoir[:0]
+}
+
+// Count returns the number of values added to Summary.
+func (s *Summary) Count() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.count
+}
+
+// Sum returns the sum of values added to Summary.
+func (s *Summary) Sum() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sum
+}
+
+// Mean returns the mean of values added to Summary, returns NaN if Summary is empty.
+func (s *Summary) Mean() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.mean
+}
+
+// Variance returns the population variance of values added to Summary, returns NaN if Summary is empty.
+func (s *Summary) Variance() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.count)
+}
+
+// SampleVariance returns the sample variance of values added to Summary, returns NaN if Summary has less than two values.
+func (s *Summary) SampleVariance() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.count < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// StdDev returns the population standard deviation of values added to Summary, returns NaN if Summary is empty.
+func (s *Summary) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation of values added to Summary, returns NaN if Summary has less than two
+// values.
+func (s *Summary) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// Min returns the minimum value added to Summary, returns NaN if Summary is empty.
+func (s *Summary) Min() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.min
+}
+
+// Max returns the maximum value added to Summary, returns NaN if Summary is empty.
+func (s *Summary) Max() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.max
+}
+
+// Percentile returns the estimated p-th percentile (p in [0, 100]) of values added to Summary, see Percentile for details.
+// Note that the result is exact if the number of values does not exceed the reservoir size.
+func (s *Summary) Percentile(p float64) float64 {
+	return s.Percentiles(p)[0]
+}
+
+// Percentiles returns the estimated percentiles of values added to Summary for each p in ps, see Percentiles for details.
+func (s *Summary) Percentiles(ps ...float64) []float64 {
+	s.mu.RLock()
+	sorted := sortedCopy(s.reservoir)
+	s.mu.RUnlock()
+	out := make([]float64, len(ps))
+	for i, p := range ps {
+		out[i] = percentileSorted(sorted, p)
+	}
+	return out
+}
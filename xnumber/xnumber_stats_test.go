@@ -0,0 +1,183 @@
+package xnumber
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestFloat64sFrom(t *testing.T) {
+	xtesting.Equal(t, Float64sFromInts([]int{}), []float64{})
+	xtesting.Equal(t, Float64sFromInts([]int{1, -2, 3}), []float64{1, -2, 3})
+	xtesting.Equal(t, Float64sFromInt64s([]int64{1, -2, 3}), []float64{1, -2, 3})
+	xtesting.Equal(t, Float64sFromUint64s([]uint64{1, 2, 3}), []float64{1, 2, 3})
+	xtesting.Equal(t, Mean(Float64sFromInts([]int{1, 2, 3, 4})), 2.5)
+}
+
+func TestDescriptiveStats(t *testing.T) {
+	for _, tc := range []struct {
+		give         []float64
+		wantSum      float64
+		wantMean     float64
+		wantMedian   float64
+		wantMode     []float64
+		wantVariance float64
+		wantSampleV  float64
+		wantMin      float64
+		wantMax      float64
+	}{
+		{[]float64{5}, 5, 5, 5, []float64{5}, 0, math.NaN(), 5, 5},
+		{[]float64{3, 1, 2}, 6, 2, 2, []float64{1, 2, 3}, 2.0 / 3, 1, 1, 3},
+		{[]float64{4, 1, 3, 2}, 10, 2.5, 2.5, []float64{1, 2, 3, 4}, 1.25, 5.0 / 3, 1, 4},
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 40, 5, 4.5, []float64{4}, 4, 32.0 / 7, 2, 9},
+		{[]float64{1, 1, 2, 2, 3}, 9, 1.8, 2, []float64{1, 2}, 0.56, 0.7, 1, 3},
+		{[]float64{-1.5, 0, 1.5}, 0, 0, 0, []float64{-1.5, 0, 1.5}, 1.5, 2.25, -1.5, 1.5},
+	} {
+		xtesting.Equal(t, Sum(tc.give), tc.wantSum)
+		xtesting.InDelta(t, Mean(tc.give), tc.wantMean, 1e-12)
+		xtesting.Equal(t, Median(tc.give), tc.wantMedian)
+		xtesting.Equal(t, Mode(tc.give), tc.wantMode)
+		xtesting.InDelta(t, Variance(tc.give), tc.wantVariance, 1e-12)
+		xtesting.InDelta(t, StdDev(tc.give), math.Sqrt(tc.wantVariance), 1e-12)
+		if math.IsNaN(tc.wantSampleV) {
+			xtesting.True(t, math.IsNaN(SampleVariance(tc.give)))
+			xtesting.True(t, math.IsNaN(SampleStdDev(tc.give)))
+		} else {
+			xtesting.InDelta(t, SampleVariance(tc.give), tc.wantSampleV, 1e-12)
+			xtesting.InDelta(t, SampleStdDev(tc.give), math.Sqrt(tc.wantSampleV), 1e-12)
+		}
+		min, max := MinMax(tc.give)
+		xtesting.Equal(t, min, tc.wantMin)
+		xtesting.Equal(t, max, tc.wantMax)
+	}
+
+	xs := []float64{3, 1, 2}
+	_ = Median(xs)
+	xtesting.Equal(t, xs, []float64{3, 1, 2}) // not modified
+
+	xtesting.Equal(t, Sum(nil), 0.0)
+	xtesting.True(t, math.IsNaN(Mean(nil)))
+	xtesting.True(t, math.IsNaN(Median(nil)))
+	xtesting.Equal(t, Mode(nil), []float64{})
+	xtesting.True(t, math.IsNaN(Variance(nil)))
+	xtesting.True(t, math.IsNaN(StdDev(nil)))
+	min, max := MinMax(nil)
+	xtesting.True(t, math.IsNaN(min) && math.IsNaN(max))
+}
+
+func TestPercentile(t *testing.T) {
+	xs := []float64{4, 2, 3, 1}
+	for _, tc := range []struct {
+		giveP float64
+		want  float64
+	}{
+		{0, 1},
+		{25, 1.75},
+		{50, 2.5},
+		{75, 3.25},
+		{90, 3.7},
+		{100, 4},
+		{-1, math.NaN()},
+		{100.1, math.NaN()},
+		{math.NaN(), math.NaN()},
+	} {
+		if math.IsNaN(tc.want) {
+			xtesting.True(t, math.IsNaN(Percentile(xs, tc.giveP)))
+		} else {
+			xtesting.InDelta(t, Percentile(xs, tc.giveP), tc.want, 1e-12)
+		}
+	}
+	xtesting.Equal(t, xs, []float64{4, 2, 3, 1})
+	xtesting.Equal(t, Percentile([]float64{7}, 99), 7.0)
+	xtesting.True(t, math.IsNaN(Percentile(nil, 50)))
+
+	ps := Percentiles([]float64{5, 4, 3, 2, 1}, 50, 90, 99)
+	xtesting.Equal(t, len(ps), 3)
+	xtesting.InDelta(t, ps[0], 3, 1e-12)
+	xtesting.InDelta(t, ps[1], 4.6, 1e-12)
+	xtesting.InDelta(t, ps[2], 4.96, 1e-12)
+	xtesting.Equal(t, Percentiles([]float64{1, 2}), []float64{})
+}
+
+func TestSummary(t *testing.T) {
+	s := NewSummary(0)
+	xtesting.Equal(t, s.size, summaryDefaultReservoirSize)
+	xtesting.Equal(t, s.Count(), int64(0))
+	xtesting.Equal(t, s.Sum(), 0.0)
+	for _, f := range []func() float64{s.Mean, s.Variance, s.SampleVariance, s.StdDev, s.SampleStdDev, s.Min, s.Max} {
+		xtesting.True(t, math.IsNaN(f()))
+	}
+	xtesting.True(t, math.IsNaN(s.Percentile(50)))
+
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	s.AddAll(data)
+	xtesting.Equal(t, s.Count(), int64(8))
+	xtesting.Equal(t, s.Sum(), 40.0)
+	xtesting.InDelta(t, s.Mean(), 5, 1e-12)
+	xtesting.InDelta(t, s.Variance(), 4, 1e-12)
+	xtesting.InDelta(t, s.StdDev(), 2, 1e-12)
+	xtesting.InDelta(t, s.SampleVariance(), 32.0/7, 1e-12)
+	xtesting.InDelta(t, s.SampleStdDev(), math.Sqrt(32.0/7), 1e-12)
+	xtesting.Equal(t, s.Min(), 2.0)
+	xtesting.Equal(t, s.Max(), 9.0)
+	xtesting.Equal(t, s.Percentile(50), Percentile(data, 50)) // exact
+	xtesting.Equal(t, s.Percentiles(0, 90, 100), Percentiles(data, 0, 90, 100))
+
+	s.Add(-1)
+	xtesting.Equal(t, s.Min(), -1.0)
+	s.Reset()
+	xtesting.Equal(t, s.Count(), int64(0))
+	xtesting.True(t, math.IsNaN(s.Mean()))
+	s.Add(3)
+	xtesting.Equal(t, s.Min(), 3.0)
+	xtesting.Equal(t, s.Max(), 3.0)
+	xtesting.Equal(t, s.Percentile(10), 3.0)
+
+	// reservoir
+	s = NewSummary(100)
+	for i := 1; i <= 10000; i++ {
+		s.Add(float64(i))
+	}
+	xtesting.Equal(t, len(s.reservoir), 100)
+	xtesting.Equal(t, s.Count(), int64(10000))
+	xtesting.Equal(t, s.Min(), 1.0)
+	xtesting.Equal(t, s.Max(), 10000.0)
+	xtesting.InDelta(t, s.Mean(), 5000.5, 1e-9)
+	xtesting.InDelta(t, s.Variance(), (10000.0*10000-1)/12, 1e-3)
+	xtesting.InDelta(t, s.Percentile(50), 5000, 2000) // estimated
+
+	// zero value
+	zero := &Summary{}
+	xtesting.True(t, math.IsNaN(zero.Percentile(50)))
+	zero.AddAll([]float64{3, 1, 2})
+	xtesting.Equal(t, zero.size, summaryDefaultReservoirSize)
+	xtesting.Equal(t, zero.Count(), int64(3))
+	xtesting.Equal(t, zero.Mean(), 2.0)
+	xtesting.Equal(t, zero.Percentile(50), 2.0)
+	xtesting.Equal(t, zero.Percentiles(0, 100), []float64{1, 3})
+}
+
+func TestSummaryConcurrent(t *testing.T) {
+	s := NewSummary(64)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 1; j <= 1000; j++ {
+				s.Add(float64(j))
+				if j%100 == 0 {
+					_ = s.Percentile(99)
+					_ = s.Mean()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	xtesting.Equal(t, s.Count(), int64(8000))
+	xtesting.Equal(t, s.Sum(), 8*500500.0)
+	xtesting.InDelta(t, s.Mean(), 500.5, 1e-9)
+	xtesting.Equal(t, s.Min(), 1.0)
+	xtesting.Equal(t, s.Max(), 1000.0)
+}
@@ -1,6 +1,7 @@
 package xreflect
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"math"
@@ -150,8 +151,12 @@ const (
 	panicInvalidDefaultType = "xreflect: parsing '%s' as the default value of field '%s' failed: %v"
 )
 
+// _textUnmarshalerType is the reflect.Type of encoding.TextUnmarshaler.
+var _textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
 // FillDefaultFields fills struct fields with "default" tag recursively, returns true if any value is set or filled, returns error if given parameter
 // is not a pointer of struct, panics when using mismatched default value type and field type.
+// Note that the value of field whose pointer implements encoding.TextUnmarshaler will be set by UnmarshalText, such as xnumber.ByteSize.
 func FillDefaultFields(s interface{}) (bool, error) {
 	val := reflect.ValueOf(s)
 	if val.Kind() != reflect.Ptr {
@@ -256,6 +261,19 @@ func fillDefaultFieldInternal(ftyp reflect.Type, fval reflect.Value, fieldTag re
 		if !ok {
 			return false
 		}
+		if fval.IsZero() && reflect.PtrTo(ftyp).Implements(_textUnmarshalerType) {
+			// set default value to the types which implement encoding.TextUnmarshaler, such as xnumber.ByteSize
+			newVal := reflect.New(ftyp)
+			if err := newVal.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(defaul)); err != nil {
+				panic(fmt.Sprintf(panicInvalidDefaultType, defaul, fieldName, err))
+			}
+			if fval.CanSet() {
+				fval.Set(newVal.Elem())
+			} else {
+				setMapValue(newVal.Elem())
+			}
+			return true
+		}
 		switch {
 		case IsIntKind(k) && fval.Int() == 0:
 			i, err := strconv.ParseInt(defaul, 10, 64)
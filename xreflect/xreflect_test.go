@@ -5,6 +5,7 @@ import (
 	"github.com/Aoi-hosizora/ahlib/xtesting"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"unsafe"
@@ -205,6 +206,22 @@ func TestMapBuckets(t *testing.T) {
 	})
 }
 
+type testTextSize int64
+
+func (t *testTextSize) UnmarshalText(text []byte) error {
+	s := string(text)
+	unit := testTextSize(1)
+	if strings.HasSuffix(s, "k") {
+		s, unit = strings.TrimSuffix(s, "k"), 1000
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*t = testTextSize(i) * unit
+	return nil
+}
+
 func TestFillDefaultFields(t *testing.T) {
 	// 1. errors
 	t.Run("errors", func(t *testing.T) {
@@ -359,6 +376,17 @@ func TestFillDefaultFields(t *testing.T) {
 		MapMap3 *map[string]*map[string]*uint `default:"1"`
 	}
 
+	type struct8 struct {
+		T1 testTextSize            `default:"2k"`
+		T2 testTextSize            `default:"3"`
+		T3 *testTextSize           `default:"4k"`
+		T4 map[string]testTextSize `default:"5k"`
+		T5 testTextSize
+	}
+	type struct9 struct {
+		T testTextSize `default:"x"`
+	}
+
 	// 3. normal tests
 	for _, tc := range []struct {
 		name       string
@@ -479,6 +507,14 @@ func TestFillDefaultFields(t *testing.T) {
 			return len(s.MapMap1) == 1 && len(s.MapMap1[""]) == 1 && len(s.MapMap2) == 2 && len(s.MapMap2[""]) == 2 && len(s.MapMap2["."]) == 2 && len(*s.MapMap3) == 1 && len(*(*s.MapMap3)[""]) == 1 &&
 				s.MapMap1[""][""] == 1 && *(s.MapMap2[""][""]) == 1 && *(s.MapMap2[""]["."]) == 1 && *(s.MapMap2["."][""]) == 1 && *(s.MapMap2["."]["."]) == 2 && *((*(*s.MapMap3)[""])[""]) == 2
 		}},
+		{"struct8", &struct8{}, false, true, func(s *struct8) bool {
+			return s.T1 == 2000 && s.T2 == 3 && *s.T3 == 4000 && len(s.T4) == 0 && s.T5 == 0
+		}},
+		{"struct8", &struct8{T1: 1, T4: map[string]testTextSize{"": 0, ".": 1}}, false, true, func(s *struct8) bool {
+			return s.T1 == 1 && s.T2 == 3 && *s.T3 == 4000 && s.T4[""] == 5000 && s.T4["."] == 1
+		}},
+		{"struct9", &struct9{T: 1}, false, false, nil},
+		{"struct9", &struct9{}, true, false, nil},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.wantPanic {
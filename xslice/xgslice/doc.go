@@ -0,0 +1,3 @@
+// Package xgslice provides the generics-based typed version of xslice, require `Go >= 1.18`. All the functions in this
+// package share the same semantics with the functions in xslice, so callers can migrate from xslice gradually.
+package xgslice
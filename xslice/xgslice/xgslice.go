@@ -0,0 +1,337 @@
+//go:build go1.18
+// +build go1.18
+
+package xgslice
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Equaller represents an equality function for two T, is used in XXXWith methods.
+type Equaller[T any] func(i, j T) bool
+
+// Lesser represents a less function for sort, see sort.Interface.
+type Lesser[T any] func(i, j T) bool
+
+const (
+	panicNilLesser = "xgslice: nil less function"
+)
+
+// defaultEqualler returns a default Equaller for comparable T, it just checks equality by `==`.
+func defaultEqualler[T comparable]() Equaller[T] {
+	return func(i, j T) bool {
+		return i == j
+	}
+}
+
+// cloneSlice clones a []T slice, note that a nil slice will be cloned to an empty slice.
+func cloneSlice[S ~[]T, T any](slice S) S {
+	newSlice := make(S, len(slice))
+	copy(newSlice, slice)
+	return newSlice
+}
+
+// ShuffleSelf shuffles the []T slice directly.
+func ShuffleSelf[S ~[]T, T any](slice S) {
+	coreShuffle(slice)
+}
+
+// Shuffle shuffles the []T slice and returns the result.
+func Shuffle[S ~[]T, T any](slice S) S {
+	newSlice := cloneSlice(slice)
+	coreShuffle(newSlice)
+	return newSlice
+}
+
+// coreShuffle is the implementation for ShuffleSelf and Shuffle.
+func coreShuffle[S ~[]T, T any](slice S) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano())) // use a local source rather than reseeding the global one
+	for i := len(slice) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// ReverseSelf reverses the []T slice directly.
+func ReverseSelf[S ~[]T, T any](slice S) {
+	coreReverse(slice)
+}
+
+// Reverse reverses the []T slice and returns the result.
+func Reverse[S ~[]T, T any](slice S) S {
+	newSlice := cloneSlice(slice)
+	coreReverse(newSlice)
+	return newSlice
+}
+
+// coreReverse is the implementation for ReverseSelf and Reverse.
+func coreReverse[S ~[]T, T any](slice S) {
+	for i, j := 0, len(slice)-1; i < j; i, j = i+1, j-1 {
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// SortSelf sorts the []T slice with less function directly.
+func SortSelf[S ~[]T, T any](slice S, less Lesser[T]) {
+	coreSort(slice, less, false)
+}
+
+// Sort sorts the []T slice with less function and returns the result.
+func Sort[S ~[]T, T any](slice S, less Lesser[T]) S {
+	newSlice := cloneSlice(slice)
+	coreSort(newSlice, less, false)
+	return newSlice
+}
+
+// StableSortSelf sorts the []T slice in stable with less function directly.
+func StableSortSelf[S ~[]T, T any](slice S, less Lesser[T]) {
+	coreSort(slice, less, true)
+}
+
+// StableSort sorts the []T slice in stable with less function and returns the result.
+func StableSort[S ~[]T, T any](slice S, less Lesser[T]) S {
+	newSlice := cloneSlice(slice)
+	coreSort(newSlice, less, true)
+	return newSlice
+}
+
+// sortSlice is a sort helper struct for []T, implements sort.Interface.
+type sortSlice[T any] struct {
+	slice []T
+	less  Lesser[T]
+}
+
+func (s sortSlice[T]) Len() int {
+	return len(s.slice)
+}
+
+func (s sortSlice[T]) Swap(i, j int) {
+	s.slice[i], s.slice[j] = s.slice[j], s.slice[i]
+}
+
+func (s sortSlice[T]) Less(i, j int) bool {
+	return s.less(s.slice[i], s.slice[j])
+}
+
+// coreSort is the implementation for SortSelf, Sort, StableSortSelf and StableSort, using sort.Sort and sort.Stable.
+func coreSort[S ~[]T, T any](slice S, less Lesser[T], stable bool) {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	ss := sortSlice[T]{slice: slice, less: less}
+	if stable {
+		sort.Stable(ss)
+	} else {
+		sort.Sort(ss)
+	}
+}
+
+// IndexOf returns the first index of value in the []T slice.
+func IndexOf[S ~[]T, T comparable](slice S, value T) int {
+	return coreIndexOf(slice, value, defaultEqualler[T]())
+}
+
+// IndexOfWith returns the first index of value in the []T slice with Equaller.
+func IndexOfWith[S ~[]T, T any](slice S, value T, equaller Equaller[T]) int {
+	return coreIndexOf(slice, value, equaller)
+}
+
+// coreIndexOf is the implementation for IndexOf and IndexOfWith.
+func coreIndexOf[S ~[]T, T any](slice S, value T, equaller Equaller[T]) int {
+	for idx, item := range slice {
+		if equaller(item, value) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Contains returns true if value is in the []T slice.
+func Contains[S ~[]T, T comparable](slice S, value T) bool {
+	return coreIndexOf(slice, value, defaultEqualler[T]()) != -1
+}
+
+// ContainsWith returns true if value is in the []T slice with Equaller.
+func ContainsWith[S ~[]T, T any](slice S, value T, equaller Equaller[T]) bool {
+	return coreIndexOf(slice, value, equaller) != -1
+}
+
+// Count returns the count of value in the []T slice.
+func Count[S ~[]T, T comparable](slice S, value T) int {
+	return coreCount(slice, value, defaultEqualler[T]())
+}
+
+// CountWith returns the count of value in the []T slice with Equaller.
+func CountWith[S ~[]T, T any](slice S, value T, equaller Equaller[T]) int {
+	return coreCount(slice, value, equaller)
+}
+
+// coreCount is the implementation for Count and CountWith.
+func coreCount[S ~[]T, T any](slice S, value T, equaller Equaller[T]) int {
+	cnt := 0
+	for _, item := range slice {
+		if equaller(item, value) {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// Delete deletes value from []T slice in n times.
+func Delete[S ~[]T, T comparable](slice S, value T, n int) S {
+	return coreDelete(slice, value, n, defaultEqualler[T]())
+}
+
+// DeleteWith deletes value from []T slice in n times with Equaller.
+func DeleteWith[S ~[]T, T any](slice S, value T, n int, equaller Equaller[T]) S {
+	return coreDelete(slice, value, n, equaller)
+}
+
+// DeleteAll deletes value from []T slice in all.
+func DeleteAll[S ~[]T, T comparable](slice S, value T) S {
+	return coreDelete(slice, value, 0, defaultEqualler[T]())
+}
+
+// DeleteAllWith deletes value from []T slice in all with Equaller.
+func DeleteAllWith[S ~[]T, T any](slice S, value T, equaller Equaller[T]) S {
+	return coreDelete(slice, value, 0, equaller)
+}
+
+// coreDelete is the implementation for Delete, DeleteWith, DeleteAll and DeleteAllWith.
+func coreDelete[S ~[]T, T any](slice S, value T, n int, equaller Equaller[T]) S {
+	if n <= 0 {
+		n = len(slice)
+	}
+	cnt := 0
+	result := make(S, 0, len(slice))
+	for _, item := range slice {
+		if cnt < n && equaller(item, value) {
+			cnt++
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// Diff returns the difference of two []T slices.
+func Diff[S ~[]T, T comparable](slice1, slice2 S) S {
+	return coreDiff(slice1, slice2, defaultEqualler[T]())
+}
+
+// DiffWith returns the difference of two []T slices with Equaller.
+func DiffWith[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) S {
+	return coreDiff(slice1, slice2, equaller)
+}
+
+// coreDiff is the implementation for Diff and DiffWith.
+func coreDiff[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) S {
+	result := make(S, 0)
+	for _, item1 := range slice1 {
+		if coreIndexOf(slice2, item1, equaller) == -1 {
+			result = append(result, item1)
+		}
+	}
+	return result
+}
+
+// Union returns the union of two []T slices.
+func Union[S ~[]T, T comparable](slice1, slice2 S) S {
+	return coreUnion(slice1, slice2, defaultEqualler[T]())
+}
+
+// UnionWith returns the union of two []T slices with Equaller.
+func UnionWith[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) S {
+	return coreUnion(slice1, slice2, equaller)
+}
+
+// coreUnion is the implementation for Union and UnionWith.
+func coreUnion[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) S {
+	result := make(S, 0, len(slice1))
+	result = append(result, slice1...)
+	for _, item2 := range slice2 {
+		if coreIndexOf(slice1, item2, equaller) == -1 {
+			result = append(result, item2)
+		}
+	}
+	return result
+}
+
+// Intersection returns the intersection of two []T slices.
+func Intersection[S ~[]T, T comparable](slice1, slice2 S) S {
+	return coreIntersection(slice1, slice2, defaultEqualler[T]())
+}
+
+// IntersectionWith returns the intersection of two []T slices with Equaller.
+func IntersectionWith[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) S {
+	return coreIntersection(slice1, slice2, equaller)
+}
+
+// coreIntersection is the implementation for Intersection and IntersectionWith.
+func coreIntersection[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) S {
+	result := make(S, 0)
+	for _, item1 := range slice1 {
+		if coreIndexOf(slice2, item1, equaller) != -1 {
+			result = append(result, item1)
+		}
+	}
+	return result
+}
+
+// ToSet removes the duplicate items from []T slice as a set.
+func ToSet[S ~[]T, T comparable](slice S) S {
+	return coreToSet(slice, defaultEqualler[T]())
+}
+
+// ToSetWith removes the duplicate items from []T slice as a set with Equaller.
+func ToSetWith[S ~[]T, T any](slice S, equaller Equaller[T]) S {
+	return coreToSet(slice, equaller)
+}
+
+// coreToSet is the implementation for ToSet and ToSetWith.
+func coreToSet[S ~[]T, T any](slice S, equaller Equaller[T]) S {
+	result := make(S, 0)
+	for _, item := range slice {
+		if coreIndexOf(result, item, equaller) == -1 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ElementMatch checks if two []T slice equal without order.
+func ElementMatch[S ~[]T, T comparable](slice1, slice2 S) bool {
+	return coreElementMatch(slice1, slice2, defaultEqualler[T]())
+}
+
+// ElementMatchWith checks if two []T slice equal without order with Equaller.
+func ElementMatchWith[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) bool {
+	return coreElementMatch(slice1, slice2, equaller)
+}
+
+// coreElementMatch is the implementation for ElementMatch and ElementMatchWith.
+func coreElementMatch[S ~[]T, T any](slice1, slice2 S, equaller Equaller[T]) bool {
+	if len(slice1) != len(slice2) {
+		return false
+	}
+	visited := make([]bool, len(slice2))
+	for _, item1 := range slice1 {
+		exist := false
+		for idx2, item2 := range slice2 {
+			if visited[idx2] {
+				continue
+			}
+			if equaller(item1, item2) {
+				visited[idx2] = true
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,227 @@
+//go:build go1.18
+// +build go1.18
+
+package xgslice
+
+import (
+	"fmt"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"testing"
+	"time"
+)
+
+type testStruct struct {
+	value int
+}
+
+func newTestStructSlice(s []int) []testStruct {
+	newSlice := make([]testStruct, len(s))
+	for idx, item := range s {
+		newSlice[idx] = testStruct{value: item}
+	}
+	return newSlice
+}
+
+func toIntSlice(s []testStruct) []int {
+	out := make([]int, len(s))
+	for idx, item := range s {
+		out[idx] = item.value
+	}
+	return out
+}
+
+var testEq Equaller[testStruct] = func(i, j testStruct) bool { return i.value == j.value }
+
+func TestShuffle(t *testing.T) {
+	for _, tc := range []struct {
+		give []int
+	}{
+		{nil},
+		{[]int{}},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{[]int{1, 2, 3, 4}},
+	} {
+		me := Reverse(Reverse(tc.give))
+		for i := 0; i < 2; i++ {
+			time.Sleep(2 * time.Nanosecond)
+			result := Shuffle(tc.give)
+			xtesting.True(t, len(tc.give) == 0 || IndexOf(tc.give, 1) == 0)
+			xtesting.NotNil(t, result)
+			xtesting.True(t, ElementMatch(result, me))
+			fmt.Println(result)
+		}
+		for i := 0; i < 2; i++ {
+			time.Sleep(2 * time.Nanosecond)
+			ShuffleSelf(tc.give)
+			xtesting.True(t, ElementMatch(tc.give, me))
+			fmt.Println(tc.give)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	type myInts []int
+	for _, tc := range []struct {
+		give myInts
+		want myInts
+	}{
+		{nil, myInts{}},
+		{myInts{}, myInts{}},
+		{myInts{0}, myInts{0}},
+		{myInts{1, 2, 3}, myInts{3, 2, 1}},
+		{myInts{1, 2, 3, 4}, myInts{4, 3, 2, 1}},
+	} {
+		result := Reverse(tc.give)
+		xtesting.Equal(t, result, tc.want)
+		ReverseSelf(tc.give)
+		if tc.give != nil {
+			xtesting.Equal(t, tc.give, tc.want)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	le := func(i, j int) bool { return i < j }
+	for _, tc := range []struct {
+		give      []int
+		giveLess  Lesser[int]
+		want      []int
+		wantPanic bool
+	}{
+		{[]int{}, nil, nil, true},
+		{[]int{}, le, []int{}, false},
+		{[]int{0}, le, []int{0}, false},
+		{[]int{1, 1, 1}, le, []int{1, 1, 1}, false},
+		{[]int{4, 3, 2, 1}, le, []int{1, 2, 3, 4}, false},
+		{[]int{8, 1, 6, 8, 1, 2}, le, []int{1, 1, 2, 6, 8, 8}, false},
+	} {
+		if tc.wantPanic {
+			xtesting.Panic(t, func() { Sort(tc.give, tc.giveLess) })
+			xtesting.Panic(t, func() { StableSort(tc.give, tc.giveLess) })
+			continue
+		}
+		me := make([]int, len(tc.give))
+		copy(me, tc.give)
+		xtesting.Equal(t, Sort(tc.give, tc.giveLess), tc.want)
+		xtesting.Equal(t, StableSort(tc.give, tc.giveLess), tc.want)
+		xtesting.Equal(t, tc.give, me)
+		SortSelf(tc.give, tc.giveLess)
+		xtesting.Equal(t, tc.give, tc.want)
+	}
+
+	type pair struct{ k, v int }
+	stable := StableSort([]pair{{2, 0}, {1, 1}, {2, 2}, {1, 3}, {0, 4}}, func(i, j pair) bool { return i.k < j.k })
+	xtesting.Equal(t, stable, []pair{{0, 4}, {1, 1}, {1, 3}, {2, 0}, {2, 2}})
+	StableSortSelf(stable, func(i, j pair) bool { return i.k > j.k })
+	xtesting.Equal(t, stable, []pair{{2, 0}, {2, 2}, {1, 1}, {1, 3}, {0, 4}})
+}
+
+func TestIndexOfAndContainsAndCount(t *testing.T) {
+	s := []int{1, 5, 2, 1, 2, 3}
+	for _, tc := range []struct {
+		giveValue int
+		wantIndex int
+		wantCount int
+	}{
+		{-1, -1, 0},
+		{0, -1, 0},
+		{1, 0, 2},
+		{2, 2, 2},
+		{3, 5, 1},
+		{4, -1, 0},
+		{5, 1, 1},
+	} {
+		xtesting.Equal(t, IndexOf(s, tc.giveValue), tc.wantIndex)
+		xtesting.Equal(t, Contains(s, tc.giveValue), tc.wantIndex != -1)
+		xtesting.Equal(t, Count(s, tc.giveValue), tc.wantCount)
+		give, giveValue := newTestStructSlice(s), testStruct{tc.giveValue}
+		xtesting.Equal(t, IndexOfWith(give, giveValue, testEq), tc.wantIndex)
+		xtesting.Equal(t, ContainsWith(give, giveValue, testEq), tc.wantIndex != -1)
+		xtesting.Equal(t, CountWith(give, giveValue, testEq), tc.wantCount)
+	}
+	xtesting.Equal(t, IndexOf([]int(nil), 0), -1)
+	xtesting.False(t, Contains([]string{}, ""))
+}
+
+func TestDelete(t *testing.T) {
+	s := []int{1, 5, 2, 1, 2, 3, 1}
+	for _, tc := range []struct {
+		giveValue int
+		giveN     int
+		want      []int
+	}{
+		{-1, 1, []int{1, 5, 2, 1, 2, 3, 1}},
+		{1, 1, []int{5, 2, 1, 2, 3, 1}},
+		{1, 2, []int{5, 2, 2, 3, 1}},
+		{1, 3, []int{5, 2, 2, 3}},
+		{1, 0, []int{5, 2, 2, 3}},
+		{1, -1, []int{5, 2, 2, 3}},
+		{2, 1, []int{1, 5, 1, 2, 3, 1}},
+		{3, 2, []int{1, 5, 2, 1, 2, 1}},
+	} {
+		xtesting.Equal(t, Delete(s, tc.giveValue, tc.giveN), tc.want)
+		give, giveValue := newTestStructSlice(s), testStruct{tc.giveValue}
+		xtesting.Equal(t, toIntSlice(DeleteWith(give, giveValue, tc.giveN, testEq)), tc.want)
+		if tc.giveN <= 0 {
+			xtesting.Equal(t, DeleteAll(s, tc.giveValue), tc.want)
+			xtesting.Equal(t, toIntSlice(DeleteAllWith(give, giveValue, testEq)), tc.want)
+		}
+	}
+	xtesting.Equal(t, s, []int{1, 5, 2, 1, 2, 3, 1})
+	xtesting.Equal(t, DeleteAll([]int(nil), 0), []int{})
+}
+
+func TestDiffUnionIntersection(t *testing.T) {
+	s := []int{1, 5, 2, 1, 5, 2, 6, 3, 2}
+	for _, tc := range []struct {
+		give2     []int
+		wantDiff  []int
+		wantUnion []int
+		wantInter []int
+	}{
+		{nil, []int{1, 5, 2, 1, 5, 2, 6, 3, 2}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2}, []int{}},
+		{[]int{1}, []int{5, 2, 5, 2, 6, 3, 2}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2}, []int{1, 1}},
+		{[]int{1, 2, 3, 4}, []int{5, 5, 6}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2, 4}, []int{1, 2, 1, 2, 3, 2}},
+		{[]int{6, 5, 4, 3, 2, 1}, []int{}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2, 4}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2}},
+		{[]int{7, 7}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2}, []int{1, 5, 2, 1, 5, 2, 6, 3, 2, 7, 7}, []int{}},
+	} {
+		xtesting.Equal(t, Diff(s, tc.give2), tc.wantDiff)
+		xtesting.Equal(t, Union(s, tc.give2), tc.wantUnion)
+		xtesting.Equal(t, Intersection(s, tc.give2), tc.wantInter)
+		give1, give2 := newTestStructSlice(s), newTestStructSlice(tc.give2)
+		xtesting.Equal(t, toIntSlice(DiffWith(give1, give2, testEq)), tc.wantDiff)
+		xtesting.Equal(t, toIntSlice(UnionWith(give1, give2, testEq)), tc.wantUnion)
+		xtesting.Equal(t, toIntSlice(IntersectionWith(give1, give2, testEq)), tc.wantInter)
+	}
+}
+
+func TestToSetAndElementMatch(t *testing.T) {
+	for _, tc := range []struct {
+		give []int
+		want []int
+	}{
+		{nil, []int{}},
+		{[]int{1}, []int{1}},
+		{[]int{1, 1, 1}, []int{1}},
+		{[]int{2, 1, 2, 3, 1}, []int{2, 1, 3}},
+	} {
+		xtesting.Equal(t, ToSet(tc.give), tc.want)
+		xtesting.Equal(t, toIntSlice(ToSetWith(newTestStructSlice(tc.give), testEq)), tc.want)
+	}
+
+	for _, tc := range []struct {
+		give1 []int
+		give2 []int
+		want  bool
+	}{
+		{nil, []int{}, true},
+		{[]int{1}, []int{1}, true},
+		{[]int{1}, []int{2}, false},
+		{[]int{1, 2, 1}, []int{1, 1, 2}, true},
+		{[]int{1, 2, 1}, []int{1, 2, 2}, false},
+		{[]int{1, 2}, []int{1, 2, 2}, false},
+	} {
+		xtesting.Equal(t, ElementMatch(tc.give1, tc.give2), tc.want)
+		xtesting.Equal(t, ElementMatchWith(newTestStructSlice(tc.give1), newTestStructSlice(tc.give2), testEq), tc.want)
+	}
+}
@@ -529,38 +529,3 @@ func coreElementMatch(slice1, slice2 innerSlice, equaller Equaller) bool {
 
 	return extra1.length() == 0 && extra2.length() == 0
 }
-
-const (
-	panicMinLargerThenMax = "xslice: min is larger than max"
-	panicStepLessThenZero = "xslice: step is less then or equals to 0"
-)
-
-// Range generates a []int slice from min index to max index with step.
-func Range(min, max, step int) []int {
-	if min > max {
-		panic(panicMinLargerThenMax)
-	} else if step <= 0 {
-		panic(panicStepLessThenZero)
-	}
-
-	out := make([]int, 0)
-	for idx := min; idx <= max; idx += step {
-		out = append(out, idx)
-	}
-	return out
-}
-
-// ReverseRange generates a reverse []int slice from max index to min index with step.
-func ReverseRange(min, max, step int) []int {
-	if min > max {
-		panic(panicMinLargerThenMax)
-	} else if step <= 0 {
-		panic(panicStepLessThenZero)
-	}
-
-	out := make([]int, 0)
-	for idx := max; idx >= min; idx -= step {
-		out = append(out, idx)
-	}
-	return out
-}
@@ -0,0 +1,284 @@
+package xslice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffOpType represents the type of DiffOp, can be DiffEqual, DiffDelete or DiffInsert.
+type DiffOpType uint8
+
+const (
+	// DiffEqual means the item exists in both slices.
+	DiffEqual DiffOpType = iota
+
+	// DiffDelete means the item only exists in the first slice.
+	DiffDelete
+
+	// DiffInsert means the item only exists in the second slice.
+	DiffInsert
+)
+
+// String returns the string value of DiffOpType.
+func (d DiffOpType) String() string {
+	switch d {
+	case DiffEqual:
+		return "equal"
+	case DiffDelete:
+		return "delete"
+	case DiffInsert:
+		return "insert"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffOp represents an operation in edit script, is returned by DiffOps.
+type DiffOp struct {
+	// Type represents the type of this operation.
+	Type DiffOpType
+
+	// Index1 represents the index of item in the first slice, and it will be -1 for DiffInsert.
+	Index1 int
+
+	// Index2 represents the index of item in the second slice, and it will be -1 for DiffDelete.
+	Index2 int
+
+	// Item represents the item, for DiffEqual it is the item in the first slice.
+	Item interface{}
+}
+
+// String returns the formatted DiffOp, format like: `-[1] a`, `+[2] b` and ` [1,2] c`.
+func (d DiffOp) String() string {
+	switch d.Type {
+	case DiffDelete:
+		return fmt.Sprintf("-[%d] %v", d.Index1, d.Item)
+	case DiffInsert:
+		return fmt.Sprintf("+[%d] %v", d.Index2, d.Item)
+	default:
+		return fmt.Sprintf(" [%d,%d] %v", d.Index1, d.Index2, d.Item)
+	}
+}
+
+// DiffOps returns the shortest edit script which transforms the first []interface{} slice to the second one, using
+// Myers' diff algorithm.
+func DiffOps(slice1, slice2 []interface{}) []DiffOp {
+	return coreDiffOps(checkInterfaceSliceParam(slice1), checkInterfaceSliceParam(slice2), defaultEqualler)
+}
+
+// DiffOpsWith returns the shortest edit script which transforms the first []interface{} slice to the second one with Equaller.
+func DiffOpsWith(slice1, slice2 []interface{}, equaller Equaller) []DiffOp {
+	return coreDiffOps(checkInterfaceSliceParam(slice1), checkInterfaceSliceParam(slice2), equaller)
+}
+
+// DiffOpsG returns the shortest edit script which transforms the first []T slice to the second one, is the generic
+// function of DiffOps.
+func DiffOpsG(slice1, slice2 interface{}) []DiffOp {
+	s1, s2 := checkTwoSliceInterfaceParam(slice1, slice2)
+	return coreDiffOps(s1, s2, defaultEqualler)
+}
+
+// DiffOpsWithG returns the shortest edit script which transforms the first []T slice to the second one with Equaller, is
+// the generic function of DiffOpsWith.
+func DiffOpsWithG(slice1, slice2 interface{}, equaller Equaller) []DiffOp {
+	s1, s2 := checkTwoSliceInterfaceParam(slice1, slice2)
+	return coreDiffOps(s1, s2, equaller)
+}
+
+// coreDiffOps is the implementation for DiffOps and DiffOpsWith, it uses the linear space refinement of Myers' algorithm, which
+// finds the middle snake and divides the problem recursively, see http://www.xmailserver.org/diff2.pdf for details.
+func coreDiffOps(slice1, slice2 innerSlice, equaller Equaller) []DiffOp {
+	n, m := slice1.length(), slice2.length()
+	df := &differ{slice1: slice1, slice2: slice2, equaller: equaller, ops: make([]DiffOp, 0, n+m)}
+	df.diff(0, n, 0, m)
+	return df.ops
+}
+
+// differ is the state used in coreDiffOps, it appends the ops of each sub-problem to ops in order.
+type differ struct {
+	slice1   innerSlice
+	slice2   innerSlice
+	equaller Equaller
+	ops      []DiffOp
+}
+
+// equal checks whether slice1[i] and slice2[j] are equal.
+func (df *differ) equal(i, j int) bool {
+	return df.equaller(df.slice1.get(i), df.slice2.get(j))
+}
+
+// diff appends the ops which transform slice1[lo1:hi1] to slice2[lo2:hi2].
+func (df *differ) diff(lo1, hi1, lo2, hi2 int) {
+	// common prefix
+	for lo1 < hi1 && lo2 < hi2 && df.equal(lo1, lo2) {
+		df.ops = append(df.ops, DiffOp{Type: DiffEqual, Index1: lo1, Index2: lo2, Item: df.slice1.get(lo1)})
+		lo1, lo2 = lo1+1, lo2+1
+	}
+	// common suffix, which is appended after the middle part
+	suffix := 0
+	for lo1 < hi1-suffix && lo2 < hi2-suffix && df.equal(hi1-suffix-1, hi2-suffix-1) {
+		suffix++
+	}
+	hi1, hi2 = hi1-suffix, hi2-suffix
+
+	if lo1 == hi1 || lo2 == hi2 {
+		df.appendChanges(lo1, hi1, lo2, hi2)
+	} else if x, y, ok := df.middleSnake(lo1, hi1, lo2, hi2); ok {
+		df.diff(lo1, x, lo2, y)
+		df.diff(x, hi1, y, hi2)
+	} else {
+		df.appendChanges(lo1, hi1, lo2, hi2)
+	}
+
+	for i := 0; i < suffix; i++ {
+		df.ops = append(df.ops, DiffOp{Type: DiffEqual, Index1: hi1 + i, Index2: hi2 + i, Item: df.slice1.get(hi1 + i)})
+	}
+}
+
+// appendChanges appends the ops which delete all items in slice1[lo1:hi1] and insert all items in slice2[lo2:hi2].
+func (df *differ) appendChanges(lo1, hi1, lo2, hi2 int) {
+	for i := lo1; i < hi1; i++ {
+		df.ops = append(df.ops, DiffOp{Type: DiffDelete, Index1: i, Index2: -1, Item: df.slice1.get(i)})
+	}
+	for j := lo2; j < hi2; j++ {
+		df.ops = append(df.ops, DiffOp{Type: DiffInsert, Index1: -1, Index2: j, Item: df.slice2.get(j)})
+	}
+}
+
+// middleSnake searches forward and backward at the same time, and returns the point where the two paths overlap, which
+// splits the problem into two smaller ones. Both ranges must be non-empty, and the memory used is O(n+m).
+func (df *differ) middleSnake(lo1, hi1, lo2, hi2 int) (int, int, bool) {
+	n, m := hi1-lo1, hi2-lo2
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	vf, vb := make([]int, 2*maxD+2), make([]int, 2*maxD+2) // furthest reaching x of forward and backward paths
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+	delta := n - m
+	odd := delta%2 != 0
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0 // trim the diagonals which run off the edge
+
+	for d := 0; d < maxD; d++ {
+		// forward
+		for k := -d + kfStart; k <= d-kfEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1] // down, insert
+			} else {
+				x = vf[offset+k-1] + 1 // right, delete
+			}
+			y := x - k
+			for x < n && y < m && df.equal(lo1+x, lo2+y) {
+				x, y = x+1, y+1
+			}
+			vf[offset+k] = x
+			if x > n {
+				kfEnd += 2
+			} else if y > m {
+				kfStart += 2
+			} else if odd {
+				if kb := offset + delta - k; kb >= 0 && kb < len(vb) && vb[kb] != -1 && x >= n-vb[kb] {
+					return lo1 + x, lo2 + y, true
+				}
+			}
+		}
+
+		// backward, x and y are counted from the end
+		for k := -d + kbStart; k <= d-kbEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && df.equal(hi1-x-1, hi2-y-1) {
+				x, y = x+1, y+1
+			}
+			vb[offset+k] = x
+			if x > n {
+				kbEnd += 2
+			} else if y > m {
+				kbStart += 2
+			} else if !odd {
+				if kf := offset + delta - k; kf >= 0 && kf < len(vf) && vf[kf] != -1 && vf[kf] >= n-x {
+					xf := vf[kf]
+					return lo1 + xf, lo2 + xf - (kf - offset), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// UnifiedDiff renders the edit script from DiffOps to a unified diff style text, and context is the number of unchanged
+// items shown around each change. Note that each item is formatted by `%v`, and an empty string will be returned if there
+// is no change.
+//
+// Format like:
+// 	@@ -1,3 +1,3 @@
+// 	 a
+// 	-b
+// 	+c
+// 	 d
+func UnifiedDiff(ops []DiffOp, context int) string {
+	if context < 0 {
+		context = 0
+	}
+
+	// pos1 and pos2 record the numbers of items before each op in two slices
+	pos1, pos2 := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	changes := make([]int, 0)
+	for idx, op := range ops {
+		pos1[idx+1], pos2[idx+1] = pos1[idx], pos2[idx]
+		if op.Type != DiffInsert {
+			pos1[idx+1]++
+		}
+		if op.Type != DiffDelete {
+			pos2[idx+1]++
+		}
+		if op.Type != DiffEqual {
+			changes = append(changes, idx)
+		}
+	}
+
+	sb := strings.Builder{}
+	for i := 0; i < len(changes); {
+		// merge the changes whose contexts overlap into one hunk
+		start := changes[i] - context
+		end := changes[i] + context + 1
+		for i++; i < len(changes) && changes[i]-context <= end; i++ {
+			end = changes[i] + context + 1
+		}
+		if start < 0 {
+			start = 0
+		}
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		len1, len2 := pos1[end]-pos1[start], pos2[end]-pos2[start]
+		start1, start2 := pos1[start], pos2[start]
+		if len1 > 0 {
+			start1++
+		}
+		if len2 > 0 {
+			start2++
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", start1, len1, start2, len2))
+		for _, op := range ops[start:end] {
+			switch op.Type {
+			case DiffDelete:
+				sb.WriteString("-")
+			case DiffInsert:
+				sb.WriteString("+")
+			default:
+				sb.WriteString(" ")
+			}
+			sb.WriteString(fmt.Sprintf("%v\n", op.Item))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,154 @@
+package xslice
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"strings"
+	"testing"
+)
+
+func TestDiffOps(t *testing.T) {
+	toSlice := func(s string) []interface{} {
+		out := make([]interface{}, 0, len(s))
+		for _, r := range s {
+			out = append(out, string(r))
+		}
+		return out
+	}
+	lcs := func(a, b string) int {
+		dp := make([][]int, len(a)+1)
+		for i := range dp {
+			dp[i] = make([]int, len(b)+1)
+		}
+		for i := 1; i <= len(a); i++ {
+			for j := 1; j <= len(b); j++ {
+				if a[i-1] == b[j-1] {
+					dp[i][j] = dp[i-1][j-1] + 1
+				} else if dp[i-1][j] > dp[i][j-1] {
+					dp[i][j] = dp[i-1][j]
+				} else {
+					dp[i][j] = dp[i][j-1]
+				}
+			}
+		}
+		return dp[len(a)][len(b)]
+	}
+
+	for _, tc := range []struct {
+		give1 string
+		give2 string
+	}{
+		{"", ""},
+		{"a", ""},
+		{"", "a"},
+		{"abc", "abc"},
+		{"abc", "xyz"},
+		{"abcabba", "cbabac"},
+		{"abcdef", "abxdef"},
+		{"aaaa", "aa"},
+		{"xaxbxc", "abc"},
+		{"the quick brown fox", "the quack brawn fix"},
+	} {
+		ops := DiffOps(toSlice(tc.give1), toSlice(tc.give2))
+		edits := 0
+		sb1, sb2 := strings.Builder{}, strings.Builder{}
+		idx1, idx2 := 0, 0
+		for _, op := range ops {
+			switch op.Type {
+			case DiffEqual:
+				xtesting.Equal(t, op.Index1, idx1)
+				xtesting.Equal(t, op.Index2, idx2)
+				xtesting.Equal(t, op.Item, string(tc.give2[idx2]))
+				sb1.WriteString(op.Item.(string))
+				sb2.WriteString(op.Item.(string))
+				idx1++
+				idx2++
+			case DiffDelete:
+				xtesting.Equal(t, op.Index1, idx1)
+				xtesting.Equal(t, op.Index2, -1)
+				sb1.WriteString(op.Item.(string))
+				idx1++
+				edits++
+			case DiffInsert:
+				xtesting.Equal(t, op.Index1, -1)
+				xtesting.Equal(t, op.Index2, idx2)
+				sb2.WriteString(op.Item.(string))
+				idx2++
+				edits++
+			}
+		}
+		xtesting.Equal(t, sb1.String(), tc.give1)
+		xtesting.Equal(t, sb2.String(), tc.give2)
+		xtesting.Equal(t, edits, len(tc.give1)+len(tc.give2)-2*lcs(tc.give1, tc.give2))
+	}
+
+	xtesting.Equal(t, DiffOps(nil, nil), []DiffOp{})
+	xtesting.Equal(t, DiffOpsG([]int{1, 2, 3}, []int{1, 3, 4}), []DiffOp{
+		{DiffEqual, 0, 0, 1}, {DiffDelete, 1, -1, 2}, {DiffEqual, 2, 1, 3}, {DiffInsert, -1, 2, 4},
+	})
+	xtesting.Panic(t, func() { DiffOpsG([]int{}, []string{}) })
+
+	type pair struct{ k, v int }
+	eq := func(i, j interface{}) bool { return i.(pair).k == j.(pair).k }
+	xtesting.Equal(t, DiffOpsWith([]interface{}{pair{1, 1}, pair{2, 2}}, []interface{}{pair{2, 0}}, eq), []DiffOp{
+		{DiffDelete, 0, -1, pair{1, 1}}, {DiffEqual, 1, 0, pair{2, 2}},
+	})
+	xtesting.Equal(t, DiffOpsWithG([]pair{{1, 1}}, []pair{{1, 0}, {3, 3}}, eq), []DiffOp{
+		{DiffEqual, 0, 0, pair{1, 1}}, {DiffInsert, -1, 1, pair{3, 3}},
+	})
+
+	// large and fully different inputs
+	large1, large2 := make([]int, 2000), make([]int, 2000)
+	for i := range large1 {
+		large1[i], large2[i] = i, -i-1
+	}
+	ops := DiffOpsG(large1, large2)
+	xtesting.Equal(t, len(ops), 4000)
+	deletes, inserts := 0, 0
+	for _, op := range ops {
+		switch op.Type {
+		case DiffDelete:
+			xtesting.Equal(t, op.Item, large1[op.Index1])
+			deletes++
+		case DiffInsert:
+			xtesting.Equal(t, op.Item, large2[op.Index2])
+			inserts++
+		}
+	}
+	xtesting.Equal(t, deletes, 2000)
+	xtesting.Equal(t, inserts, 2000)
+}
+
+func TestDiffOpString(t *testing.T) {
+	xtesting.Equal(t, DiffEqual.String(), "equal")
+	xtesting.Equal(t, DiffDelete.String(), "delete")
+	xtesting.Equal(t, DiffInsert.String(), "insert")
+	xtesting.Equal(t, DiffOpType(3).String(), "unknown")
+	xtesting.Equal(t, DiffOp{DiffEqual, 1, 2, "a"}.String(), " [1,2] a")
+	xtesting.Equal(t, DiffOp{DiffDelete, 1, -1, "a"}.String(), "-[1] a")
+	xtesting.Equal(t, DiffOp{DiffInsert, -1, 2, "a"}.String(), "+[2] a")
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	for _, tc := range []struct {
+		give1       []int
+		give2       []int
+		giveContext int
+		want        string
+	}{
+		{[]int{}, []int{}, 3, ""},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, 3, ""},
+		{[]int{}, []int{1, 2}, 3, "@@ -0,0 +1,2 @@\n+1\n+2\n"},
+		{[]int{1, 2}, []int{}, 3, "@@ -1,2 +0,0 @@\n-1\n-2\n"},
+		{[]int{1, 2, 3}, []int{1, 4, 3}, 1, "@@ -1,3 +1,3 @@\n 1\n-2\n+4\n 3\n"},
+		{[]int{1, 2, 3}, []int{1, 4, 3}, 0, "@@ -2,1 +2,1 @@\n-2\n+4\n"},
+		{[]int{1, 2, 3}, []int{1, 4, 3}, -1, "@@ -2,1 +2,1 @@\n-2\n+4\n"},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, []int{0, 2, 3, 4, 5, 6, 7, 8}, 1,
+			"@@ -1,2 +1,2 @@\n-1\n+0\n 2\n@@ -8,2 +8,1 @@\n 8\n-9\n"},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, []int{0, 2, 3, 4, 5, 6, 7, 8}, 3,
+			"@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n@@ -6,4 +6,3 @@\n 6\n 7\n 8\n-9\n"},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, []int{0, 2, 3, 4, 5, 6, 7, 8}, 4,
+			"@@ -1,9 +1,8 @@\n-1\n+0\n 2\n 3\n 4\n 5\n 6\n 7\n 8\n-9\n"},
+	} {
+		xtesting.Equal(t, UnifiedDiff(DiffOpsG(tc.give1, tc.give2), tc.giveContext), tc.want)
+	}
+}
@@ -322,6 +322,36 @@ func makeInnerSlice(slice innerSlice, length, capacity int) innerSlice {
 	return checkInterfaceSliceParam(newSlice)
 }
 
+// makeInnerSliceWithElemType creates a new innerSlice by given element type, a nil type means []interface{} innerSlice.
+func makeInnerSliceWithElemType(elemType reflect.Type, length, capacity int) innerSlice {
+	if length < 0 {
+		panic(panicIndexOutOfRange)
+	}
+	if capacity < length {
+		capacity = length
+	}
+
+	if elemType == nil || elemType == interfaceType {
+		newSlice := make([]interface{}, length, capacity)
+		return checkInterfaceSliceParam(newSlice)
+	}
+	newSlice := reflect.MakeSlice(reflect.SliceOf(elemType), length, capacity).Interface()
+	return checkSliceInterfaceParam(newSlice)
+}
+
+var (
+	interfaceType      = reflect.TypeOf((*interface{})(nil)).Elem()
+	interfaceSliceType = reflect.TypeOf([]interface{}{})
+)
+
+// sliceTypeOf returns the slice type of given innerSlice, such as []interface{} or []T.
+func sliceTypeOf(slice innerSlice) reflect.Type {
+	if slice, ok := slice.(*innerInterfaceWrappedSlice); ok {
+		return slice.typ
+	}
+	return interfaceSliceType
+}
+
 // =========
 // sortSlice
 // =========
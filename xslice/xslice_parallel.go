@@ -0,0 +1,211 @@
+package xslice
+
+import (
+	"context"
+	"fmt"
+	"github.com/Aoi-hosizora/ahlib/xruntime"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	panicNilContext  = "xslice: nil context"
+	panicNilFunction = "xslice: nil function"
+)
+
+// PanicError represents an error which is recovered from a panic in the function of ParallelXXX, with the runtime trace stack.
+type PanicError struct {
+	// Value represents the value passed to panic.
+	Value interface{}
+
+	// Stack represents the trace stack where the panic happened.
+	Stack xruntime.TraceStack
+}
+
+// Error returns the formatted PanicError.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("xslice: panic in parallel function: %v", p.Value)
+}
+
+// ParallelMap maps each item of the []interface{} slice using mapper in parallel with given number of workers, and returns
+// the result in the same order of slice. Here workers will be runtime.NumCPU() if it is less than or equal to 0, and the
+// first error returned or panicked from mapper will be returned and the rest work will be cancelled. The error of ctx will
+// only be returned if ctx is done before all items are handled.
+func ParallelMap(ctx context.Context, slice []interface{}, workers int, mapper func(interface{}) (interface{}, error)) ([]interface{}, error) {
+	result, err := coreParallelMap(ctx, checkInterfaceSliceParam(slice), workers, mapper, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.actual().([]interface{}), nil
+}
+
+// ParallelMapG maps each item of the []T slice using mapper in parallel, and returns the result in []U, is the generic
+// function of ParallelMap. Here elemType is the type of U, and the result will be []interface{} if elemType is nil.
+func ParallelMapG(ctx context.Context, slice interface{}, workers int, mapper func(interface{}) (interface{}, error), elemType reflect.Type) (interface{}, error) {
+	result, err := coreParallelMap(ctx, checkSliceInterfaceParam(slice), workers, mapper, elemType)
+	if err != nil {
+		return nil, err
+	}
+	return result.actual(), nil
+}
+
+// coreParallelMap is the implementation for ParallelMap.
+func coreParallelMap(ctx context.Context, slice innerSlice, workers int, mapper func(interface{}) (interface{}, error), elemType reflect.Type) (innerSlice, error) {
+	if mapper == nil {
+		panic(panicNilMapper)
+	}
+	mapped := make([]interface{}, slice.length())
+	err := coreParallelForEach(ctx, slice, workers, func(index int, item interface{}) error {
+		out, err := mapper(item)
+		if err != nil {
+			return err
+		}
+		mapped[index] = out
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := makeInnerSliceWithElemType(elemType, 0, len(mapped))
+	for _, item := range mapped {
+		result.append(item)
+	}
+	return result, nil
+}
+
+// ParallelFilter returns the items which satisfy the predicate from the []interface{} slice, the predicate is called in
+// parallel with given number of workers, see ParallelMap for details.
+func ParallelFilter(ctx context.Context, slice []interface{}, workers int, predicate func(interface{}) (bool, error)) ([]interface{}, error) {
+	result, err := coreParallelFilter(ctx, checkInterfaceSliceParam(slice), workers, predicate)
+	if err != nil {
+		return nil, err
+	}
+	return result.actual().([]interface{}), nil
+}
+
+// ParallelFilterG returns the items which satisfy the predicate from the []T slice, the predicate is called in parallel,
+// is the generic function of ParallelFilter.
+func ParallelFilterG(ctx context.Context, slice interface{}, workers int, predicate func(interface{}) (bool, error)) (interface{}, error) {
+	result, err := coreParallelFilter(ctx, checkSliceInterfaceParam(slice), workers, predicate)
+	if err != nil {
+		return nil, err
+	}
+	return result.actual(), nil
+}
+
+// coreParallelFilter is the implementation for ParallelFilter.
+func coreParallelFilter(ctx context.Context, slice innerSlice, workers int, predicate func(interface{}) (bool, error)) (innerSlice, error) {
+	if predicate == nil {
+		panic(panicNilPredicate)
+	}
+	matched := make([]bool, slice.length())
+	err := coreParallelForEach(ctx, slice, workers, func(index int, item interface{}) error {
+		ok, err := predicate(item)
+		if err != nil {
+			return err
+		}
+		matched[index] = ok
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := makeInnerSlice(slice, 0, 0)
+	for idx, ok := range matched {
+		if ok {
+			result.append(slice.get(idx))
+		}
+	}
+	return result, nil
+}
+
+// ParallelForEach invokes fn for each item of the []interface{} slice in parallel with given number of workers, see
+// ParallelMap for details.
+func ParallelForEach(ctx context.Context, slice []interface{}, workers int, fn func(index int, item interface{}) error) error {
+	return coreParallelForEach(ctx, checkInterfaceSliceParam(slice), workers, fn)
+}
+
+// ParallelForEachG invokes fn for each item of the []T slice in parallel, is the generic function of ParallelForEach.
+func ParallelForEachG(ctx context.Context, slice interface{}, workers int, fn func(index int, item interface{}) error) error {
+	return coreParallelForEach(ctx, checkSliceInterfaceParam(slice), workers, fn)
+}
+
+// coreParallelForEach is the implementation for ParallelForEach, ParallelMap and ParallelFilter.
+func coreParallelForEach(ctx context.Context, slice innerSlice, workers int, fn func(index int, item interface{}) error) error {
+	if ctx == nil {
+		panic(panicNilContext)
+	}
+	if fn == nil {
+		panic(panicNilFunction)
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > slice.length() {
+		workers = slice.length()
+	}
+
+	innerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstErr error
+	var errOnce sync.Once
+	setError := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var stopped int32 // set if some items are skipped
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				if innerCtx.Err() != nil {
+					atomic.StoreInt32(&stopped, 1)
+					continue // drain
+				}
+				if err := callWithRecover(fn, idx, slice.get(idx)); err != nil {
+					setError(err)
+				}
+			}
+		}()
+	}
+
+feed:
+	for idx := 0; idx < slice.length(); idx++ {
+		select {
+		case <-innerCtx.Done():
+			atomic.StoreInt32(&stopped, 1)
+			break feed
+		case indexes <- idx:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if atomic.LoadInt32(&stopped) == 1 {
+		return ctx.Err() // only report the error of ctx when the iteration is stopped early
+	}
+	return nil
+}
+
+// callWithRecover invokes fn and returns a PanicError if fn panics.
+func callWithRecover(fn func(int, interface{}) error, index int, item interface{}) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: xruntime.RuntimeTraceStack(2)}
+		}
+	}()
+	return fn(index, item)
+}
@@ -0,0 +1,142 @@
+package xslice
+
+import (
+	"context"
+	"errors"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	ctx := context.Background()
+	toStr := func(i interface{}) (interface{}, error) { return strconv.Itoa(i.(int)), nil }
+
+	s1 := make([]interface{}, 100)
+	s2 := make([]int, 100)
+	want1 := make([]interface{}, 100)
+	want2 := make([]string, 100)
+	for i := 0; i < 100; i++ {
+		s1[i], s2[i] = i, i
+		want1[i], want2[i] = strconv.Itoa(i), strconv.Itoa(i)
+	}
+
+	for _, workers := range []int{-1, 0, 1, 3, 8, 200} {
+		result, err := ParallelMap(ctx, s1, workers, toStr)
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, result, want1)
+		resultG, err := ParallelMapG(ctx, s2, workers, toStr, reflect.TypeOf(""))
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, resultG, want2)
+	}
+
+	result, err := ParallelMap(ctx, nil, 4, toStr)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, result, []interface{}{})
+	resultG, err := ParallelMapG(ctx, []int{}, 4, toStr, nil)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, resultG, []interface{}{})
+
+	xtesting.Panic(t, func() { _, _ = ParallelMap(ctx, s1, 4, nil) })
+	xtesting.Panic(t, func() { _, _ = ParallelMap(nil, s1, 4, toStr) })
+	xtesting.Panic(t, func() { _, _ = ParallelMapG(ctx, s2, 4, toStr, reflect.TypeOf(0)) })
+}
+
+func TestParallelFilter(t *testing.T) {
+	ctx := context.Background()
+	even := func(i interface{}) (bool, error) { return i.(int)%2 == 0, nil }
+
+	result, err := ParallelFilter(ctx, []interface{}{1, 2, 3, 4, 5, 6}, 3, even)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, result, []interface{}{2, 4, 6})
+	resultG, err := ParallelFilterG(ctx, []int{6, 5, 4, 3, 2, 1}, 2, even)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, resultG, []int{6, 4, 2})
+	resultG, err = ParallelFilterG(ctx, []int(nil), 2, even)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, resultG, []int{})
+
+	xtesting.Panic(t, func() { _, _ = ParallelFilterG(ctx, []int{}, 2, nil) })
+}
+
+func TestParallelForEach(t *testing.T) {
+	ctx := context.Background()
+
+	// normal
+	var sum int64
+	err := ParallelForEachG(ctx, []int{1, 2, 3, 4, 5}, 2, func(index int, item interface{}) error {
+		atomic.AddInt64(&sum, int64(index*item.(int)))
+		return nil
+	})
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, sum, int64(0+2+6+12+20))
+	xtesting.Panic(t, func() { _ = ParallelForEach(ctx, []interface{}{}, 1, nil) })
+
+	// error
+	testErr := errors.New("test")
+	var called int64
+	err = ParallelForEach(ctx, make([]interface{}, 1000), 4, func(index int, _ interface{}) error {
+		atomic.AddInt64(&called, 1)
+		if index == 10 {
+			return testErr
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	xtesting.Equal(t, err, testErr)
+	xtesting.True(t, atomic.LoadInt64(&called) < 1000)
+	_, err = ParallelMapG(ctx, []int{1, 2, 3}, 2, func(interface{}) (interface{}, error) { return nil, testErr }, nil)
+	xtesting.Equal(t, err, testErr)
+	_, err = ParallelFilter(ctx, []interface{}{1, 2, 3}, 2, func(interface{}) (bool, error) { return false, testErr })
+	xtesting.Equal(t, err, testErr)
+
+	// panic
+	err = ParallelForEachG(ctx, []int{1, 2, 3}, 3, func(_ int, item interface{}) error {
+		if item.(int) == 2 {
+			panic("test panic")
+		}
+		return nil
+	})
+	pe, ok := err.(*PanicError)
+	xtesting.True(t, ok)
+	xtesting.Equal(t, pe.Value, "test panic")
+	xtesting.Equal(t, pe.Error(), "xslice: panic in parallel function: test panic")
+	xtesting.True(t, len(pe.Stack) > 0)
+	found := false
+	for _, frame := range pe.Stack {
+		if strings.Contains(frame.LineText, `panic("test panic")`) {
+			found = true
+		}
+	}
+	xtesting.True(t, found)
+
+	// cancel
+	cancelCtx, cancel := context.WithCancel(ctx)
+	called = 0
+	err = ParallelForEach(cancelCtx, make([]interface{}, 1000), 2, func(index int, _ interface{}) error {
+		if atomic.AddInt64(&called, 1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+	xtesting.Equal(t, err, context.Canceled)
+	xtesting.True(t, atomic.LoadInt64(&called) < 1000)
+	_, err = ParallelMap(cancelCtx, []interface{}{1}, 1, func(i interface{}) (interface{}, error) { return i, nil })
+	xtesting.Equal(t, err, context.Canceled)
+
+	// cancel after all work completed
+	cancelCtx, cancel = context.WithCancel(ctx)
+	called = 0
+	err = ParallelForEach(cancelCtx, make([]interface{}, 3), 1, func(index int, _ interface{}) error {
+		if atomic.AddInt64(&called, 1) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, atomic.LoadInt64(&called), int64(3))
+}
@@ -0,0 +1,175 @@
+package xslice
+
+import (
+	"math"
+)
+
+const (
+	panicZeroStep = "xslice: step is 0 or not finite"
+)
+
+// RangeBound represents whether the end bound is included in range, can be RangeInclusive or RangeExclusive.
+type RangeBound uint8
+
+const (
+	// RangeInclusive means the end bound is included, that is [start, end].
+	RangeInclusive RangeBound = iota
+
+	// RangeExclusive means the end bound is excluded, that is [start, end).
+	RangeExclusive
+)
+
+// Range generates a []int slice from start to end (inclusive) with step. Note that it counts down if step is negative,
+// and returns an empty slice if start cannot reach end by step, such as Range(2, 1, 1) and Range(1, 2, -1).
+func Range(start, end, step int) []int {
+	it := NewRangeIter(int64(start), int64(end), int64(step), RangeInclusive)
+	out := make([]int, 0)
+	for it.Next() {
+		out = append(out, int(it.Value()))
+	}
+	return out
+}
+
+// ReverseRange generates a reverse []int slice from max to min (inclusive) with step, that is Range(max, min, -step).
+func ReverseRange(min, max, step int) []int {
+	return Range(max, min, -step)
+}
+
+// RangeInt64 generates a []int64 slice from start to end with step and bound option, see Range for details.
+func RangeInt64(start, end, step int64, bound RangeBound) []int64 {
+	it := NewRangeIter(start, end, step, bound)
+	out := make([]int64, 0)
+	for it.Next() {
+		out = append(out, it.Value())
+	}
+	return out
+}
+
+// RangeFloat64 generates a []float64 slice from start to end with step and bound option, see Range and FloatRangeIter
+// for details.
+func RangeFloat64(start, end, step float64, bound RangeBound) []float64 {
+	it := NewFloatRangeIter(start, end, step, bound)
+	out := make([]float64, 0)
+	for it.Next() {
+		out = append(out, it.Value())
+	}
+	return out
+}
+
+// RangeIter represents a lazy iterator of int64 range, which generates values without allocation.
+//
+// Example:
+// 	it := NewRangeIter(0, 1<<40, 1, RangeExclusive)
+// 	for it.Next() {
+// 		v := it.Value()
+// 	}
+type RangeIter struct {
+	next    int64
+	end     int64
+	step    int64
+	bound   RangeBound
+	value   int64
+	started bool
+	done    bool
+}
+
+// NewRangeIter creates a RangeIter from start to end with step and bound option, it counts down if step is negative,
+// and panics if step is 0.
+func NewRangeIter(start, end, step int64, bound RangeBound) *RangeIter {
+	if step == 0 {
+		panic(panicZeroStep)
+	}
+	return &RangeIter{next: start, end: end, step: step, bound: bound}
+}
+
+// Next advances the iterator to the next value, and returns false if the range is exhausted.
+func (r *RangeIter) Next() bool {
+	if r.done {
+		return false
+	}
+	if r.started {
+		// check overflow before advancing
+		if (r.step > 0 && r.value > math.MaxInt64-r.step) || (r.step < 0 && r.value < math.MinInt64-r.step) {
+			r.done = true
+			return false
+		}
+		r.next = r.value + r.step
+	}
+	r.started = true
+
+	var inRange bool
+	if r.step > 0 {
+		inRange = r.next < r.end || (r.bound == RangeInclusive && r.next == r.end)
+	} else {
+		inRange = r.next > r.end || (r.bound == RangeInclusive && r.next == r.end)
+	}
+	if !inRange {
+		r.done = true
+		return false
+	}
+	r.value = r.next
+	return true
+}
+
+// Value returns the current value of the iterator, note that Next must be called before Value.
+func (r *RangeIter) Value() int64 {
+	return r.value
+}
+
+// FloatRangeIter represents a lazy iterator of float64 range, which generates values without allocation. Note that the
+// n-th value is calculated by `start + n*step` rather than accumulating, to avoid the accumulated rounding error, and a
+// tiny tolerance is used when comparing with the end bound, so RangeFloat64(0, 1, 0.1, RangeInclusive) includes 1.
+type FloatRangeIter struct {
+	start float64
+	end   float64
+	step  float64
+	bound RangeBound
+	index int64
+	value float64
+	done  bool
+}
+
+// NewFloatRangeIter creates a FloatRangeIter from start to end with step and bound option, it counts down if step is
+// negative, and panics if step is 0, NaN or infinite.
+func NewFloatRangeIter(start, end, step float64, bound RangeBound) *FloatRangeIter {
+	if step == 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		panic(panicZeroStep)
+	}
+	return &FloatRangeIter{start: start, end: end, step: step, bound: bound}
+}
+
+// Next advances the iterator to the next value, and returns false if the range is exhausted.
+func (f *FloatRangeIter) Next() bool {
+	if f.done {
+		return false
+	}
+	next := f.start + float64(f.index)*f.step
+	eps := math.Abs(f.step) * 1e-9
+
+	var inRange bool
+	if f.step > 0 {
+		if f.bound == RangeInclusive {
+			inRange = next <= f.end+eps
+		} else {
+			inRange = next < f.end-eps
+		}
+	} else {
+		if f.bound == RangeInclusive {
+			inRange = next >= f.end-eps
+		} else {
+			inRange = next > f.end+eps
+		}
+	}
+	if !inRange || math.IsNaN(next) {
+		f.done = true
+		return false
+	}
+	f.index++
+	f.value = next
+	return true
+}
+
+// Value returns the current value of the iterator, note that Next must be called before Value.
+func (f *FloatRangeIter) Value() float64 {
+	return f.value
+}
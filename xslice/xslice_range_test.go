@@ -0,0 +1,116 @@
+package xslice
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math"
+	"testing"
+)
+
+func TestRangeWithNegativeStep(t *testing.T) {
+	for _, tc := range []struct {
+		giveStart int
+		giveEnd   int
+		giveStep  int
+		want      []int
+	}{
+		{-3, 3, 3, []int{-3, 0, 3}},
+		{1, 1, -1, []int{1}},
+		{10, 1, -3, []int{10, 7, 4, 1}},
+		{3, -3, -2, []int{3, 1, -1, -3}},
+	} {
+		xtesting.Equal(t, Range(tc.giveStart, tc.giveEnd, tc.giveStep), tc.want)
+	}
+	xtesting.Equal(t, ReverseRange(2, 1, -1), []int{1, 2})
+}
+
+func TestRangeInt64(t *testing.T) {
+	for _, tc := range []struct {
+		giveStart int64
+		giveEnd   int64
+		giveStep  int64
+		giveBound RangeBound
+		want      []int64
+	}{
+		{0, 0, 1, RangeInclusive, []int64{0}},
+		{0, 0, 1, RangeExclusive, []int64{}},
+		{0, 3, 1, RangeInclusive, []int64{0, 1, 2, 3}},
+		{0, 3, 1, RangeExclusive, []int64{0, 1, 2}},
+		{0, 4, 2, RangeExclusive, []int64{0, 2}},
+		{0, 5, 2, RangeExclusive, []int64{0, 2, 4}},
+		{3, 0, -1, RangeInclusive, []int64{3, 2, 1, 0}},
+		{3, 0, -1, RangeExclusive, []int64{3, 2, 1}},
+		{0, 3, -1, RangeExclusive, []int64{}},
+		{math.MaxInt64 - 2, math.MaxInt64, 1, RangeInclusive, []int64{math.MaxInt64 - 2, math.MaxInt64 - 1, math.MaxInt64}},
+		{math.MaxInt64 - 2, math.MaxInt64, 2, RangeInclusive, []int64{math.MaxInt64 - 2, math.MaxInt64}},
+		{math.MaxInt64 - 2, math.MaxInt64, 3, RangeInclusive, []int64{math.MaxInt64 - 2}},
+		{math.MinInt64 + 1, math.MinInt64, -1, RangeInclusive, []int64{math.MinInt64 + 1, math.MinInt64}},
+		{math.MinInt64 + 1, math.MinInt64, -2, RangeInclusive, []int64{math.MinInt64 + 1}},
+	} {
+		xtesting.Equal(t, RangeInt64(tc.giveStart, tc.giveEnd, tc.giveStep, tc.giveBound), tc.want)
+	}
+	xtesting.Panic(t, func() { RangeInt64(0, 1, 0, RangeInclusive) })
+}
+
+func TestRangeFloat64(t *testing.T) {
+	for _, tc := range []struct {
+		giveStart float64
+		giveEnd   float64
+		giveStep  float64
+		giveBound RangeBound
+		want      []float64
+	}{
+		{0, 0, 1, RangeInclusive, []float64{0}},
+		{0, 0, 1, RangeExclusive, []float64{}},
+		{0, 1, 0.25, RangeInclusive, []float64{0, 0.25, 0.5, 0.75, 1}},
+		{0, 1, 0.25, RangeExclusive, []float64{0, 0.25, 0.5, 0.75}},
+		{1, 0, -0.5, RangeInclusive, []float64{1, 0.5, 0}},
+		{1, 0, -0.5, RangeExclusive, []float64{1, 0.5}},
+		{0, 1, -0.5, RangeInclusive, []float64{}},
+		{0, 1, 0.3, RangeInclusive, []float64{0, 0.3, 0.6, 0.8999999999999999}},
+	} {
+		xtesting.Equal(t, RangeFloat64(tc.giveStart, tc.giveEnd, tc.giveStep, tc.giveBound), tc.want)
+	}
+
+	// no accumulated rounding error
+	r := RangeFloat64(0, 1, 0.1, RangeInclusive)
+	xtesting.Equal(t, len(r), 11)
+	for idx, v := range r {
+		xtesting.InDelta(t, v, float64(idx)/10, 1e-12)
+	}
+	xtesting.Equal(t, len(RangeFloat64(0, 1, 0.1, RangeExclusive)), 10)
+
+	xtesting.Panic(t, func() { RangeFloat64(0, 1, 0, RangeInclusive) })
+	xtesting.Panic(t, func() { RangeFloat64(0, 1, math.NaN(), RangeInclusive) })
+	xtesting.Panic(t, func() { RangeFloat64(0, 1, math.Inf(1), RangeInclusive) })
+	xtesting.Panic(t, func() { RangeFloat64(1, 0, math.Inf(-1), RangeInclusive) })
+	xtesting.Equal(t, RangeFloat64(math.NaN(), 1, 1, RangeInclusive), []float64{})
+}
+
+func TestRangeIter(t *testing.T) {
+	it := NewRangeIter(0, 1<<40, 1<<38, RangeExclusive)
+	values := make([]int64, 0)
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	xtesting.Equal(t, values, []int64{0, 1 << 38, 2 << 38, 3 << 38})
+	xtesting.False(t, it.Next())
+	xtesting.Equal(t, it.Value(), int64(3<<38))
+	xtesting.Panic(t, func() { NewRangeIter(0, 1, 0, RangeInclusive) })
+
+	fit := NewFloatRangeIter(1, 0, -0.25, RangeExclusive)
+	fvalues := make([]float64, 0)
+	for fit.Next() {
+		fvalues = append(fvalues, fit.Value())
+	}
+	xtesting.Equal(t, fvalues, []float64{1, 0.75, 0.5, 0.25})
+	xtesting.False(t, fit.Next())
+	xtesting.Panic(t, func() { NewFloatRangeIter(0, 1, 0, RangeInclusive) })
+
+	// lazy iteration over a huge range
+	it = NewRangeIter(0, math.MaxInt64, 1, RangeInclusive)
+	cnt := 0
+	for it.Next() && cnt < 1000 {
+		cnt++
+	}
+	xtesting.Equal(t, it.Value(), int64(1000))
+}
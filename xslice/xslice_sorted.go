@@ -0,0 +1,298 @@
+package xslice
+
+import (
+	"container/heap"
+)
+
+const (
+	panicNoSliceToMerge = "xslice: no slice to merge"
+)
+
+// Note that all the functions in this file require the given slices to be sorted by the given Lesser in ascending order,
+// and two items i and j are regarded as equal when both less(i, j) and less(j, i) return false.
+
+// BinarySearch returns the index of the first item which equals to value in the sorted []interface{} slice, and returns
+// -1 if value is not found.
+func BinarySearch(slice []interface{}, value interface{}, less Lesser) int {
+	return coreBinarySearch(checkInterfaceSliceParam(slice), value, less)
+}
+
+// BinarySearchG returns the index of the first item which equals to value in the sorted []T slice, is the generic function
+// of BinarySearch.
+func BinarySearchG(slice interface{}, value interface{}, less Lesser) int {
+	s, v := checkSliceInterfaceAndElemParam(slice, value)
+	return coreBinarySearch(s, v, less)
+}
+
+// coreBinarySearch is the implementation for BinarySearch.
+func coreBinarySearch(slice innerSlice, value interface{}, less Lesser) int {
+	idx := coreLowerBound(slice, value, less)
+	if idx < slice.length() && !less(value, slice.get(idx)) {
+		return idx
+	}
+	return -1
+}
+
+// LowerBound returns the index of the first item which is not less than value in the sorted []interface{} slice, and
+// returns the length of slice if there is no such item.
+func LowerBound(slice []interface{}, value interface{}, less Lesser) int {
+	return coreLowerBound(checkInterfaceSliceParam(slice), value, less)
+}
+
+// LowerBoundG returns the index of the first item which is not less than value in the sorted []T slice, is the generic
+// function of LowerBound.
+func LowerBoundG(slice interface{}, value interface{}, less Lesser) int {
+	s, v := checkSliceInterfaceAndElemParam(slice, value)
+	return coreLowerBound(s, v, less)
+}
+
+// coreLowerBound is the implementation for LowerBound.
+func coreLowerBound(slice innerSlice, value interface{}, less Lesser) int {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	lo, hi := 0, slice.length()
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if less(slice.get(mid), value) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first item which is greater than value in the sorted []interface{} slice, and
+// returns the length of slice if there is no such item.
+func UpperBound(slice []interface{}, value interface{}, less Lesser) int {
+	return coreUpperBound(checkInterfaceSliceParam(slice), value, less)
+}
+
+// UpperBoundG returns the index of the first item which is greater than value in the sorted []T slice, is the generic
+// function of UpperBound.
+func UpperBoundG(slice interface{}, value interface{}, less Lesser) int {
+	s, v := checkSliceInterfaceAndElemParam(slice, value)
+	return coreUpperBound(s, v, less)
+}
+
+// coreUpperBound is the implementation for UpperBound.
+func coreUpperBound(slice innerSlice, value interface{}, less Lesser) int {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	lo, hi := 0, slice.length()
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if !less(value, slice.get(mid)) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// InsertSorted inserts value into the sorted []interface{} slice and keeps it sorted, returns the result. Note that value
+// will be inserted after all the items which equal to it.
+func InsertSorted(slice []interface{}, value interface{}, less Lesser) []interface{} {
+	return coreInsertSorted(checkInterfaceSliceParam(slice), value, less).actual().([]interface{})
+}
+
+// InsertSortedG inserts value into the sorted []T slice and keeps it sorted, returns the result, is the generic function
+// of InsertSorted.
+func InsertSortedG(slice interface{}, value interface{}, less Lesser) interface{} {
+	s, v := checkSliceInterfaceAndElemParam(slice, value)
+	return coreInsertSorted(s, v, less).actual()
+}
+
+// coreInsertSorted is the implementation for InsertSorted.
+func coreInsertSorted(slice innerSlice, value interface{}, less Lesser) innerSlice {
+	idx := coreUpperBound(slice, value, less)
+	result := makeInnerSlice(slice, 0, slice.length()+1)
+	for i := 0; i < idx; i++ {
+		result.append(slice.get(i))
+	}
+	result.append(value)
+	for i := idx; i < slice.length(); i++ {
+		result.append(slice.get(i))
+	}
+	return result
+}
+
+// MergeSorted merges some sorted []interface{} slices into a new sorted slice in k-way, note that the merge is stable, that
+// is equal items keep the order of given slices.
+func MergeSorted(less Lesser, slices ...[]interface{}) []interface{} {
+	inners := make([]innerSlice, len(slices))
+	for idx, slice := range slices {
+		inners[idx] = checkInterfaceSliceParam(slice)
+	}
+	result := checkInterfaceSliceParam(make([]interface{}, 0))
+	return coreMergeSorted(result, inners, less).actual().([]interface{})
+}
+
+// MergeSortedG merges some sorted []T slices into a new sorted []T slice in k-way, is the generic function of MergeSorted.
+// Note that at least one slice is required, and all the slices must have the same type.
+func MergeSortedG(less Lesser, slices ...interface{}) interface{} {
+	if len(slices) == 0 {
+		panic(panicNoSliceToMerge)
+	}
+	inners := make([]innerSlice, len(slices))
+	for idx, slice := range slices {
+		s, _ := checkTwoSliceInterfaceParam(slice, slices[0])
+		inners[idx] = s
+	}
+	result := makeInnerSlice(inners[0], 0, 0)
+	return coreMergeSorted(result, inners, less).actual()
+}
+
+// mergeCursor represents the current position of a slice in k-way merge.
+type mergeCursor struct {
+	slice innerSlice
+	order int // the index of slice in given slices
+	index int // the current index of item in slice
+}
+
+// mergeHeap is a min-heap of mergeCursor used in k-way merge, implements heap.Interface.
+type mergeHeap struct {
+	cursors []*mergeCursor
+	less    Lesser
+}
+
+func (h *mergeHeap) Len() int {
+	return len(h.cursors)
+}
+
+func (h *mergeHeap) Less(i, j int) bool {
+	ci, cj := h.cursors[i], h.cursors[j]
+	itemI, itemJ := ci.slice.get(ci.index), cj.slice.get(cj.index)
+	if h.less(itemI, itemJ) {
+		return true
+	}
+	if h.less(itemJ, itemI) {
+		return false
+	}
+	// equal items are ordered by the index of their input slices, so that MergeSorted is stable
+	return ci.order < cj.order
+}
+
+func (h *mergeHeap) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *mergeHeap) Push(x interface{}) {
+	h.cursors = append(h.cursors, x.(*mergeCursor))
+}
+
+func (h *mergeHeap) Pop() interface{} {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
+
+// coreMergeSorted is the implementation for MergeSorted.
+func coreMergeSorted(result innerSlice, slices []innerSlice, less Lesser) innerSlice {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	h := &mergeHeap{cursors: make([]*mergeCursor, 0, len(slices)), less: less}
+	for idx, slice := range slices {
+		if slice.length() > 0 {
+			h.cursors = append(h.cursors, &mergeCursor{slice: slice, order: idx, index: 0})
+		}
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		top := h.cursors[0]
+		result.append(top.slice.get(top.index))
+		top.index++
+		if top.index < top.slice.length() {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return result
+}
+
+// IsSorted checks if the []interface{} slice is sorted in ascending order by less function.
+func IsSorted(slice []interface{}, less Lesser) bool {
+	return coreIsSorted(checkInterfaceSliceParam(slice), less)
+}
+
+// IsSortedG checks if the []T slice is sorted in ascending order by less function, is the generic function of IsSorted.
+func IsSortedG(slice interface{}, less Lesser) bool {
+	return coreIsSorted(checkSliceInterfaceParam(slice), less)
+}
+
+// coreIsSorted is the implementation for IsSorted.
+func coreIsSorted(slice innerSlice, less Lesser) bool {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	for idx := slice.length() - 1; idx > 0; idx-- {
+		if less(slice.get(idx), slice.get(idx-1)) {
+			return false
+		}
+	}
+	return true
+}
+
+// DedupSorted removes the duplicate items from the sorted []interface{} slice, which is faster than ToSet.
+func DedupSorted(slice []interface{}, less Lesser) []interface{} {
+	return coreDedupSorted(checkInterfaceSliceParam(slice), less).actual().([]interface{})
+}
+
+// DedupSortedG removes the duplicate items from the sorted []T slice, is the generic function of DedupSorted.
+func DedupSortedG(slice interface{}, less Lesser) interface{} {
+	return coreDedupSorted(checkSliceInterfaceParam(slice), less).actual()
+}
+
+// coreDedupSorted is the implementation for DedupSorted.
+func coreDedupSorted(slice innerSlice, less Lesser) innerSlice {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	result := makeInnerSlice(slice, 0, 0)
+	for idx := 0; idx < slice.length(); idx++ {
+		item := slice.get(idx)
+		if idx == 0 || less(slice.get(idx-1), item) {
+			result.append(item)
+		}
+	}
+	return result
+}
+
+// IntersectSorted returns the intersection of two sorted []interface{} slices, which has the same result with Intersection
+// but is faster.
+func IntersectSorted(slice1, slice2 []interface{}, less Lesser) []interface{} {
+	return coreIntersectSorted(checkInterfaceSliceParam(slice1), checkInterfaceSliceParam(slice2), less).actual().([]interface{})
+}
+
+// IntersectSortedG returns the intersection of two sorted []T slices, is the generic function of IntersectSorted.
+func IntersectSortedG(slice1, slice2 interface{}, less Lesser) interface{} {
+	s1, s2 := checkTwoSliceInterfaceParam(slice1, slice2)
+	return coreIntersectSorted(s1, s2, less).actual()
+}
+
+// coreIntersectSorted is the implementation for IntersectSorted.
+func coreIntersectSorted(slice1, slice2 innerSlice, less Lesser) innerSlice {
+	if less == nil {
+		panic(panicNilLesser)
+	}
+	result := makeInnerSlice(slice1, 0, 0)
+	i2 := 0
+	for i1 := 0; i1 < slice1.length(); i1++ {
+		item1 := slice1.get(i1)
+		for i2 < slice2.length() && less(slice2.get(i2), item1) {
+			i2++
+		}
+		if i2 == slice2.length() {
+			break
+		}
+		if !less(item1, slice2.get(i2)) {
+			result.append(item1)
+		}
+	}
+	return result
+}
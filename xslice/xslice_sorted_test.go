@@ -0,0 +1,164 @@
+package xslice
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"testing"
+)
+
+func TestBinarySearch(t *testing.T) {
+	le := func(i, j interface{}) bool { return i.(int) < j.(int) }
+	s1 := []interface{}{1, 2, 2, 2, 4, 5, 7}
+	s2 := []int{1, 2, 2, 2, 4, 5, 7}
+
+	for _, tc := range []struct {
+		giveValue int
+		wantIndex int
+		wantLower int
+		wantUpper int
+	}{
+		{0, -1, 0, 0},
+		{1, 0, 0, 1},
+		{2, 1, 1, 4},
+		{3, -1, 4, 4},
+		{4, 4, 4, 5},
+		{5, 5, 5, 6},
+		{6, -1, 6, 6},
+		{7, 6, 6, 7},
+		{8, -1, 7, 7},
+	} {
+		xtesting.Equal(t, BinarySearch(s1, tc.giveValue, le), tc.wantIndex)
+		xtesting.Equal(t, LowerBound(s1, tc.giveValue, le), tc.wantLower)
+		xtesting.Equal(t, UpperBound(s1, tc.giveValue, le), tc.wantUpper)
+		xtesting.Equal(t, BinarySearchG(s2, tc.giveValue, le), tc.wantIndex)
+		xtesting.Equal(t, LowerBoundG(s2, tc.giveValue, le), tc.wantLower)
+		xtesting.Equal(t, UpperBoundG(s2, tc.giveValue, le), tc.wantUpper)
+	}
+
+	xtesting.Equal(t, BinarySearch(nil, 0, le), -1)
+	xtesting.Equal(t, LowerBoundG([]int{}, 0, le), 0)
+	xtesting.Equal(t, UpperBoundG([]int(nil), 0, le), 0)
+	xtesting.Panic(t, func() { BinarySearch(s1, 0, nil) })
+	xtesting.Panic(t, func() { UpperBound(s1, 0, nil) })
+	xtesting.Panic(t, func() { LowerBoundG(s2, "0", le) })
+}
+
+func TestInsertSorted(t *testing.T) {
+	type pair struct{ k, v int }
+	le := func(i, j interface{}) bool { return i.(pair).k < j.(pair).k }
+
+	for _, tc := range []struct {
+		give      []interface{}
+		giveValue pair
+		want      []interface{}
+	}{
+		{nil, pair{1, 0}, []interface{}{pair{1, 0}}},
+		{[]interface{}{pair{1, 1}}, pair{0, 0}, []interface{}{pair{0, 0}, pair{1, 1}}},
+		{[]interface{}{pair{1, 1}}, pair{1, 0}, []interface{}{pair{1, 1}, pair{1, 0}}},
+		{[]interface{}{pair{1, 1}, pair{3, 3}}, pair{2, 2}, []interface{}{pair{1, 1}, pair{2, 2}, pair{3, 3}}},
+		{[]interface{}{pair{1, 1}, pair{3, 3}}, pair{4, 4}, []interface{}{pair{1, 1}, pair{3, 3}, pair{4, 4}}},
+	} {
+		xtesting.Equal(t, InsertSorted(tc.give, tc.giveValue, le), tc.want)
+	}
+
+	for _, tc := range []struct {
+		give      []pair
+		giveValue pair
+		want      []pair
+	}{
+		{nil, pair{1, 0}, []pair{{1, 0}}},
+		{[]pair{{1, 1}}, pair{0, 0}, []pair{{0, 0}, {1, 1}}},
+		{[]pair{{1, 1}, {1, 2}}, pair{1, 0}, []pair{{1, 1}, {1, 2}, {1, 0}}},
+		{[]pair{{1, 1}, {3, 3}}, pair{2, 2}, []pair{{1, 1}, {2, 2}, {3, 3}}},
+	} {
+		xtesting.Equal(t, InsertSortedG(tc.give, tc.giveValue, le), tc.want)
+	}
+
+	s := []pair{{1, 1}, {3, 3}}
+	_ = InsertSortedG(s, pair{2, 2}, le)
+	xtesting.Equal(t, s, []pair{{1, 1}, {3, 3}})
+	xtesting.Panic(t, func() { InsertSorted(nil, 0, nil) })
+}
+
+func TestMergeSorted(t *testing.T) {
+	type pair struct{ k, v int }
+	le := func(i, j interface{}) bool { return i.(pair).k < j.(pair).k }
+	leInt := func(i, j interface{}) bool { return i.(int) < j.(int) }
+
+	xtesting.Equal(t, MergeSorted(leInt), []interface{}{})
+	xtesting.Equal(t, MergeSorted(leInt, nil, []interface{}{}), []interface{}{})
+	xtesting.Equal(t, MergeSorted(leInt, []interface{}{1, 4, 7}, nil, []interface{}{2, 5}, []interface{}{0, 3, 6, 9}),
+		[]interface{}{0, 1, 2, 3, 4, 5, 6, 7, 9})
+	xtesting.Equal(t, MergeSorted(le, []interface{}{pair{1, 1}, pair{2, 1}}, []interface{}{pair{1, 2}, pair{2, 2}}, []interface{}{pair{1, 3}}),
+		[]interface{}{pair{1, 1}, pair{1, 2}, pair{1, 3}, pair{2, 1}, pair{2, 2}})
+
+	xtesting.Equal(t, MergeSortedG(leInt, []int{}), []int{})
+	xtesting.Equal(t, MergeSortedG(leInt, []int(nil), []int{3}), []int{3})
+	xtesting.Equal(t, MergeSortedG(leInt, []int{1, 4, 7}, []int{2, 5}, []int{0, 3, 6, 9}), []int{0, 1, 2, 3, 4, 5, 6, 7, 9})
+	xtesting.Equal(t, MergeSortedG(le, []pair{{2, 1}, {2, 2}}, []pair{{1, 3}, {2, 3}}), []pair{{1, 3}, {2, 1}, {2, 2}, {2, 3}})
+
+	xtesting.Panic(t, func() { MergeSorted(nil, []interface{}{1}) })
+	xtesting.Panic(t, func() { MergeSortedG(leInt) })
+	xtesting.Panic(t, func() { MergeSortedG(leInt, []int{}, []string{}) })
+	xtesting.Panic(t, func() { MergeSortedG(leInt, []int{}, nil) })
+}
+
+func TestIsSortedAndDedupSorted(t *testing.T) {
+	le := func(i, j interface{}) bool { return i.(int) < j.(int) }
+
+	for _, tc := range []struct {
+		give       []int
+		wantSorted bool
+		wantDedup  []int
+	}{
+		{nil, true, []int{}},
+		{[]int{1}, true, []int{1}},
+		{[]int{1, 1, 1}, true, []int{1}},
+		{[]int{1, 2, 2, 3, 3, 3}, true, []int{1, 2, 3}},
+		{[]int{1, 3, 2}, false, nil},
+		{[]int{2, 1}, false, nil},
+	} {
+		xtesting.Equal(t, IsSortedG(tc.give, le), tc.wantSorted)
+		give := make([]interface{}, len(tc.give))
+		for idx, item := range tc.give {
+			give[idx] = item
+		}
+		xtesting.Equal(t, IsSorted(give, le), tc.wantSorted)
+		if tc.wantSorted {
+			xtesting.Equal(t, DedupSortedG(tc.give, le), tc.wantDedup)
+			xtesting.Equal(t, DedupSorted(give, le), ToSet(give))
+		}
+	}
+
+	xtesting.Panic(t, func() { IsSorted(nil, nil) })
+	xtesting.Panic(t, func() { DedupSortedG([]int{}, nil) })
+}
+
+func TestIntersectSorted(t *testing.T) {
+	le := func(i, j interface{}) bool { return i.(int) < j.(int) }
+	s1 := []interface{}{1, 1, 2, 3, 5, 5, 6}
+	s2 := []int{1, 1, 2, 3, 5, 5, 6}
+
+	for _, tc := range []struct {
+		give2 []int
+		want  []int
+	}{
+		{nil, []int{}},
+		{[]int{0}, []int{}},
+		{[]int{1}, []int{1, 1}},
+		{[]int{1, 5}, []int{1, 1, 5, 5}},
+		{[]int{2, 3, 4}, []int{2, 3}},
+		{[]int{6, 7}, []int{6}},
+		{[]int{0, 1, 2, 3, 4, 5, 6, 7}, []int{1, 1, 2, 3, 5, 5, 6}},
+	} {
+		xtesting.Equal(t, IntersectSortedG(s2, tc.give2, le), tc.want)
+		xtesting.Equal(t, IntersectSortedG(s2, tc.give2, le), IntersectionG(s2, tc.give2))
+		give2 := make([]interface{}, len(tc.give2))
+		for idx, item := range tc.give2 {
+			give2[idx] = item
+		}
+		xtesting.Equal(t, IntersectSorted(s1, give2, le), Intersection(s1, give2))
+	}
+
+	xtesting.Panic(t, func() { IntersectSorted(nil, nil, nil) })
+	xtesting.Panic(t, func() { IntersectSortedG([]int{}, []string{}, le) })
+}
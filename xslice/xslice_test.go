@@ -258,6 +258,28 @@ func TestCloneAndMakeSlice(t *testing.T) {
 			xtesting.Equal(t, makeInnerSlice(tc.giveType, tc.giveLen, tc.giveCap).actual(), tc.want)
 		}
 	}
+
+	for _, tc := range []struct {
+		giveType  reflect.Type
+		giveLen   int
+		giveCap   int
+		want      interface{}
+		wantPanic bool
+	}{
+		{nil, -1, 0, nil, true},
+		{nil, 0, 0, []interface{}{}, false},
+		{nil, 2, 0, []interface{}{nil, nil}, false},
+		{reflect.TypeOf((*interface{})(nil)).Elem(), 1, 1, []interface{}{nil}, false},
+		{reflect.TypeOf(0), 0, 0, []int{}, false},
+		{reflect.TypeOf(0), 2, 1, []int{0, 0}, false},
+		{reflect.TypeOf([]int{}), 1, 0, [][]int{nil}, false},
+	} {
+		if tc.wantPanic {
+			xtesting.Panic(t, func() { makeInnerSliceWithElemType(tc.giveType, tc.giveLen, tc.giveCap) })
+		} else {
+			xtesting.Equal(t, makeInnerSliceWithElemType(tc.giveType, tc.giveLen, tc.giveCap).actual(), tc.want)
+		}
+	}
 }
 
 func TestShuffle(t *testing.T) {
@@ -1013,9 +1035,9 @@ func TestRange(t *testing.T) {
 		{1, 10, 2, []int{1, 3, 5, 7, 9}, false},
 		{0, 9, 2, []int{0, 2, 4, 6, 8}, false},
 
-		{2, 1, 1, nil, true},
+		{2, 1, 1, []int{}, false},
 		{1, 2, 0, nil, true},
-		{1, 2, -1, nil, true},
+		{1, 2, -1, []int{}, false},
 	} {
 		if tc.wantPanic {
 			xtesting.Panic(t, func() { Range(tc.giveMin, tc.giveMax, tc.giveStep) })
@@ -1041,9 +1063,9 @@ func TestReverseRange(t *testing.T) {
 		{1, 10, 2, []int{10, 8, 6, 4, 2}, false},
 		{0, 9, 2, []int{9, 7, 5, 3, 1}, false},
 
-		{2, 1, 1, nil, true},
+		{2, 1, 1, []int{}, false},
 		{1, 2, 0, nil, true},
-		{1, 2, -1, nil, true},
+		{1, 2, -1, []int{}, false},
 	} {
 		if tc.wantPanic {
 			xtesting.Panic(t, func() { ReverseRange(tc.giveMin, tc.giveMax, tc.giveStep) })
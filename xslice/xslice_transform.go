@@ -0,0 +1,394 @@
+package xslice
+
+import (
+	"reflect"
+)
+
+const (
+	panicNilMapper      = "xslice: nil mapper function"
+	panicNilPredicate   = "xslice: nil predicate function"
+	panicNilReducer     = "xslice: nil reducer function"
+	panicNilKeyFunc     = "xslice: nil key function"
+	panicNonPositiveLen = "xslice: size is less then or equals to 0"
+)
+
+// Pair represents a pair of two items, is used in Zip and Unzip.
+type Pair struct {
+	First  interface{}
+	Second interface{}
+}
+
+// Map maps each item of the []interface{} slice using mapper and returns the result.
+func Map(slice []interface{}, mapper func(interface{}) interface{}) []interface{} {
+	return coreMap(checkInterfaceSliceParam(slice), mapper, nil).actual().([]interface{})
+}
+
+// MapG maps each item of the []T slice using mapper and returns the result in []U, is the generic function of Map.
+// Here elemType is the type of U, and the result will be []interface{} if elemType is nil.
+func MapG(slice interface{}, mapper func(interface{}) interface{}, elemType reflect.Type) interface{} {
+	return coreMap(checkSliceInterfaceParam(slice), mapper, elemType).actual()
+}
+
+// coreMap is the implementation for Map.
+func coreMap(slice innerSlice, mapper func(interface{}) interface{}, elemType reflect.Type) innerSlice {
+	if mapper == nil {
+		panic(panicNilMapper)
+	}
+	result := makeInnerSliceWithElemType(elemType, 0, slice.length())
+	for idx := 0; idx < slice.length(); idx++ {
+		result.append(mapper(slice.get(idx)))
+	}
+	return result
+}
+
+// FlatMap maps each item of the []interface{} slice to a []interface{} slice using mapper, and returns the flattened result.
+func FlatMap(slice []interface{}, mapper func(interface{}) []interface{}) []interface{} {
+	if mapper == nil {
+		panic(panicNilMapper)
+	}
+	wrapped := func(item interface{}) interface{} {
+		return mapper(item)
+	}
+	return coreFlatMap(checkInterfaceSliceParam(slice), wrapped, nil).actual().([]interface{})
+}
+
+// FlatMapG maps each item of the []T slice to a []U slice using mapper, and returns the flattened result in []U, is the
+// generic function of FlatMap. Here elemType is the type of U, and the result will be []interface{} if elemType is nil.
+func FlatMapG(slice interface{}, mapper func(interface{}) interface{}, elemType reflect.Type) interface{} {
+	return coreFlatMap(checkSliceInterfaceParam(slice), mapper, elemType).actual()
+}
+
+// coreFlatMap is the implementation for FlatMap.
+func coreFlatMap(slice innerSlice, mapper func(interface{}) interface{}, elemType reflect.Type) innerSlice {
+	if mapper == nil {
+		panic(panicNilMapper)
+	}
+	result := makeInnerSliceWithElemType(elemType, 0, slice.length())
+	for idx := 0; idx < slice.length(); idx++ {
+		mapped := checkSliceInterfaceParam(mapper(slice.get(idx)))
+		for i := 0; i < mapped.length(); i++ {
+			result.append(mapped.get(i))
+		}
+	}
+	return result
+}
+
+// Filter returns the items which satisfy the predicate from the []interface{} slice.
+func Filter(slice []interface{}, predicate func(interface{}) bool) []interface{} {
+	return coreFilter(checkInterfaceSliceParam(slice), predicate).actual().([]interface{})
+}
+
+// FilterG returns the items which satisfy the predicate from the []T slice, is the generic function of Filter.
+func FilterG(slice interface{}, predicate func(interface{}) bool) interface{} {
+	return coreFilter(checkSliceInterfaceParam(slice), predicate).actual()
+}
+
+// coreFilter is the implementation for Filter.
+func coreFilter(slice innerSlice, predicate func(interface{}) bool) innerSlice {
+	matched, _ := corePartition(slice, predicate)
+	return matched
+}
+
+// Reduce reduces the []interface{} slice from left to right to a single value using reducer, with initial as the first accumulator.
+func Reduce(slice []interface{}, initial interface{}, reducer func(acc, item interface{}) interface{}) interface{} {
+	return coreReduce(checkInterfaceSliceParam(slice), initial, reducer)
+}
+
+// ReduceG reduces the []T slice from left to right to a single value using reducer, with initial as the first accumulator,
+// is the generic function of Reduce.
+func ReduceG(slice interface{}, initial interface{}, reducer func(acc, item interface{}) interface{}) interface{} {
+	return coreReduce(checkSliceInterfaceParam(slice), initial, reducer)
+}
+
+// coreReduce is the implementation for Reduce.
+func coreReduce(slice innerSlice, initial interface{}, reducer func(acc, item interface{}) interface{}) interface{} {
+	if reducer == nil {
+		panic(panicNilReducer)
+	}
+	acc := initial
+	for idx := 0; idx < slice.length(); idx++ {
+		acc = reducer(acc, slice.get(idx))
+	}
+	return acc
+}
+
+// GroupBy groups the []interface{} slice by the key from keyFunc, note that the key must be comparable. It returns the keys
+// in the order of first occurrence, and the groups which share the same order with keys, so the result can be put into
+// xorderedmap.OrderedMap in order directly.
+func GroupBy(slice []interface{}, keyFunc func(interface{}) interface{}) ([]interface{}, [][]interface{}) {
+	keys, groups := coreGroupBy(checkInterfaceSliceParam(slice), keyFunc)
+	return keys, groups.actual().([][]interface{})
+}
+
+// GroupByG groups the []T slice by the key from keyFunc, and returns the keys and the groups in [][]T, is the generic function
+// of GroupBy.
+func GroupByG(slice interface{}, keyFunc func(interface{}) interface{}) ([]interface{}, interface{}) {
+	keys, groups := coreGroupBy(checkSliceInterfaceParam(slice), keyFunc)
+	return keys, groups.actual()
+}
+
+// coreGroupBy is the implementation for GroupBy.
+func coreGroupBy(slice innerSlice, keyFunc func(interface{}) interface{}) ([]interface{}, innerSlice) {
+	if keyFunc == nil {
+		panic(panicNilKeyFunc)
+	}
+	keys := make([]interface{}, 0)
+	indexes := make(map[interface{}]int)
+	groups := make([]innerSlice, 0)
+	for idx := 0; idx < slice.length(); idx++ {
+		item := slice.get(idx)
+		key := keyFunc(item)
+		i, ok := indexes[key]
+		if !ok {
+			i = len(keys)
+			indexes[key] = i
+			keys = append(keys, key)
+			groups = append(groups, makeInnerSlice(slice, 0, 0))
+		}
+		groups[i].append(item)
+	}
+
+	result := makeInnerSliceWithElemType(sliceTypeOf(slice), 0, len(groups))
+	for _, group := range groups {
+		result.append(group.actual())
+	}
+	return keys, result
+}
+
+// Partition splits the []interface{} slice into two slices, the first one contains the items which satisfy the predicate,
+// and the second one contains the rest items.
+func Partition(slice []interface{}, predicate func(interface{}) bool) ([]interface{}, []interface{}) {
+	matched, unmatched := corePartition(checkInterfaceSliceParam(slice), predicate)
+	return matched.actual().([]interface{}), unmatched.actual().([]interface{})
+}
+
+// PartitionG splits the []T slice into two slices by predicate, is the generic function of Partition.
+func PartitionG(slice interface{}, predicate func(interface{}) bool) (interface{}, interface{}) {
+	matched, unmatched := corePartition(checkSliceInterfaceParam(slice), predicate)
+	return matched.actual(), unmatched.actual()
+}
+
+// corePartition is the implementation for Partition and Filter.
+func corePartition(slice innerSlice, predicate func(interface{}) bool) (innerSlice, innerSlice) {
+	if predicate == nil {
+		panic(panicNilPredicate)
+	}
+	matched := makeInnerSlice(slice, 0, 0)
+	unmatched := makeInnerSlice(slice, 0, 0)
+	for idx := 0; idx < slice.length(); idx++ {
+		item := slice.get(idx)
+		if predicate(item) {
+			matched.append(item)
+		} else {
+			unmatched.append(item)
+		}
+	}
+	return matched, unmatched
+}
+
+// Chunk splits the []interface{} slice into chunks with given size, note that the last chunk may be smaller than size.
+func Chunk(slice []interface{}, size int) [][]interface{} {
+	return coreChunk(checkInterfaceSliceParam(slice), size).actual().([][]interface{})
+}
+
+// ChunkG splits the []T slice into chunks with given size, and returns the result in [][]T, is the generic function of Chunk.
+func ChunkG(slice interface{}, size int) interface{} {
+	return coreChunk(checkSliceInterfaceParam(slice), size).actual()
+}
+
+// coreChunk is the implementation for Chunk.
+func coreChunk(slice innerSlice, size int) innerSlice {
+	if size <= 0 {
+		panic(panicNonPositiveLen)
+	}
+	result := makeInnerSliceWithElemType(sliceTypeOf(slice), 0, (slice.length()+size-1)/size)
+	for start := 0; start < slice.length(); start += size {
+		end := start + size
+		if end > slice.length() {
+			end = slice.length()
+		}
+		result.append(coreSubSlice(slice, start, end).actual())
+	}
+	return result
+}
+
+// Window returns all the sliding windows with given size of the []interface{} slice, the result will be empty if the
+// length of slice is less than size.
+func Window(slice []interface{}, size int) [][]interface{} {
+	return coreWindow(checkInterfaceSliceParam(slice), size).actual().([][]interface{})
+}
+
+// WindowG returns all the sliding windows with given size of the []T slice in [][]T, is the generic function of Window.
+func WindowG(slice interface{}, size int) interface{} {
+	return coreWindow(checkSliceInterfaceParam(slice), size).actual()
+}
+
+// coreWindow is the implementation for Window.
+func coreWindow(slice innerSlice, size int) innerSlice {
+	if size <= 0 {
+		panic(panicNonPositiveLen)
+	}
+	result := makeInnerSliceWithElemType(sliceTypeOf(slice), 0, 0)
+	for start := 0; start+size <= slice.length(); start++ {
+		result.append(coreSubSlice(slice, start, start+size).actual())
+	}
+	return result
+}
+
+// coreSubSlice copies the items in [index1, index2) of the innerSlice to a new innerSlice.
+func coreSubSlice(slice innerSlice, index1, index2 int) innerSlice {
+	result := makeInnerSlice(slice, 0, index2-index1)
+	for _, item := range slice.slice(index1, index2) {
+		result.append(item)
+	}
+	return result
+}
+
+// Zip combines two []interface{} slices into a Pair slice, the length of result is the minimum length of the two slices.
+func Zip(slice1, slice2 []interface{}) []Pair {
+	return coreZip(checkInterfaceSliceParam(slice1), checkInterfaceSliceParam(slice2))
+}
+
+// ZipG combines []T1 and []T2 slices into a Pair slice, is the generic function of Zip.
+func ZipG(slice1, slice2 interface{}) []Pair {
+	return coreZip(checkSliceInterfaceParam(slice1), checkSliceInterfaceParam(slice2))
+}
+
+// coreZip is the implementation for Zip.
+func coreZip(slice1, slice2 innerSlice) []Pair {
+	length := slice1.length()
+	if slice2.length() < length {
+		length = slice2.length()
+	}
+	result := make([]Pair, length)
+	for idx := 0; idx < length; idx++ {
+		result[idx] = Pair{First: slice1.get(idx), Second: slice2.get(idx)}
+	}
+	return result
+}
+
+// Unzip splits a Pair slice into two []interface{} slices, is the reverse function of Zip.
+func Unzip(pairs []Pair) ([]interface{}, []interface{}) {
+	slice1, slice2 := coreUnzip(pairs, nil, nil)
+	return slice1.actual().([]interface{}), slice2.actual().([]interface{})
+}
+
+// UnzipG splits a Pair slice into []T1 and []T2 slices, is the generic function of Unzip. Here elemType1 and elemType2 are
+// the types of T1 and T2, and []interface{} will be used if the type is nil.
+func UnzipG(pairs []Pair, elemType1, elemType2 reflect.Type) (interface{}, interface{}) {
+	slice1, slice2 := coreUnzip(pairs, elemType1, elemType2)
+	return slice1.actual(), slice2.actual()
+}
+
+// coreUnzip is the implementation for Unzip.
+func coreUnzip(pairs []Pair, elemType1, elemType2 reflect.Type) (innerSlice, innerSlice) {
+	slice1 := makeInnerSliceWithElemType(elemType1, 0, len(pairs))
+	slice2 := makeInnerSliceWithElemType(elemType2, 0, len(pairs))
+	for _, pair := range pairs {
+		slice1.append(pair.First)
+		slice2.append(pair.Second)
+	}
+	return slice1, slice2
+}
+
+// DistinctBy removes the items which have duplicate key from keyFunc in the []interface{} slice, note that the key must be
+// comparable, and the first item of each key is kept.
+func DistinctBy(slice []interface{}, keyFunc func(interface{}) interface{}) []interface{} {
+	return coreDistinctBy(checkInterfaceSliceParam(slice), keyFunc).actual().([]interface{})
+}
+
+// DistinctByG removes the items which have duplicate key from keyFunc in the []T slice, is the generic function of DistinctBy.
+func DistinctByG(slice interface{}, keyFunc func(interface{}) interface{}) interface{} {
+	return coreDistinctBy(checkSliceInterfaceParam(slice), keyFunc).actual()
+}
+
+// coreDistinctBy is the implementation for DistinctBy.
+func coreDistinctBy(slice innerSlice, keyFunc func(interface{}) interface{}) innerSlice {
+	if keyFunc == nil {
+		panic(panicNilKeyFunc)
+	}
+	result := makeInnerSlice(slice, 0, 0)
+	visited := make(map[interface{}]struct{})
+	for idx := 0; idx < slice.length(); idx++ {
+		item := slice.get(idx)
+		key := keyFunc(item)
+		if _, ok := visited[key]; !ok {
+			visited[key] = struct{}{}
+			result.append(item)
+		}
+	}
+	return result
+}
+
+// Take returns the first n items of the []interface{} slice, the whole slice will be returned if n is larger than its length.
+func Take(slice []interface{}, n int) []interface{} {
+	s := checkInterfaceSliceParam(slice)
+	return coreSubSlice(s, 0, clampLength(n, s.length())).actual().([]interface{})
+}
+
+// TakeG returns the first n items of the []T slice, is the generic function of Take.
+func TakeG(slice interface{}, n int) interface{} {
+	s := checkSliceInterfaceParam(slice)
+	return coreSubSlice(s, 0, clampLength(n, s.length())).actual()
+}
+
+// Drop returns the items of the []interface{} slice except the first n items.
+func Drop(slice []interface{}, n int) []interface{} {
+	s := checkInterfaceSliceParam(slice)
+	return coreSubSlice(s, clampLength(n, s.length()), s.length()).actual().([]interface{})
+}
+
+// DropG returns the items of the []T slice except the first n items, is the generic function of Drop.
+func DropG(slice interface{}, n int) interface{} {
+	s := checkSliceInterfaceParam(slice)
+	return coreSubSlice(s, clampLength(n, s.length()), s.length()).actual()
+}
+
+// clampLength clamps n to [0, length].
+func clampLength(n, length int) int {
+	if n < 0 {
+		return 0
+	}
+	if n > length {
+		return length
+	}
+	return n
+}
+
+// TakeWhile returns the longest prefix of the []interface{} slice whose items satisfy the predicate.
+func TakeWhile(slice []interface{}, predicate func(interface{}) bool) []interface{} {
+	s := checkInterfaceSliceParam(slice)
+	return coreSubSlice(s, 0, coreSplitWhile(s, predicate)).actual().([]interface{})
+}
+
+// TakeWhileG returns the longest prefix of the []T slice whose items satisfy the predicate, is the generic function of TakeWhile.
+func TakeWhileG(slice interface{}, predicate func(interface{}) bool) interface{} {
+	s := checkSliceInterfaceParam(slice)
+	return coreSubSlice(s, 0, coreSplitWhile(s, predicate)).actual()
+}
+
+// DropWhile returns the items of the []interface{} slice except the longest prefix whose items satisfy the predicate.
+func DropWhile(slice []interface{}, predicate func(interface{}) bool) []interface{} {
+	s := checkInterfaceSliceParam(slice)
+	return coreSubSlice(s, coreSplitWhile(s, predicate), s.length()).actual().([]interface{})
+}
+
+// DropWhileG returns the items of the []T slice except the longest prefix whose items satisfy the predicate, is the generic
+// function of DropWhile.
+func DropWhileG(slice interface{}, predicate func(interface{}) bool) interface{} {
+	s := checkSliceInterfaceParam(slice)
+	return coreSubSlice(s, coreSplitWhile(s, predicate), s.length()).actual()
+}
+
+// coreSplitWhile is the implementation for TakeWhile and DropWhile, it returns the index of the first item which does not
+// satisfy the predicate.
+func coreSplitWhile(slice innerSlice, predicate func(interface{}) bool) int {
+	if predicate == nil {
+		panic(panicNilPredicate)
+	}
+	idx := 0
+	for idx < slice.length() && predicate(slice.get(idx)) {
+		idx++
+	}
+	return idx
+}
@@ -0,0 +1,241 @@
+package xslice
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	toStr := func(i interface{}) interface{} { return strconv.Itoa(i.(int)) }
+
+	for _, tc := range []struct {
+		give []interface{}
+		want []interface{}
+	}{
+		{nil, []interface{}{}},
+		{[]interface{}{}, []interface{}{}},
+		{[]interface{}{1}, []interface{}{"1"}},
+		{[]interface{}{1, 2, 3}, []interface{}{"1", "2", "3"}},
+	} {
+		xtesting.Equal(t, Map(tc.give, toStr), tc.want)
+	}
+	xtesting.Panic(t, func() { Map([]interface{}{}, nil) })
+
+	for _, tc := range []struct {
+		give     []int
+		giveType reflect.Type
+		want     interface{}
+	}{
+		{nil, reflect.TypeOf(""), []string{}},
+		{[]int{}, reflect.TypeOf(""), []string{}},
+		{[]int{1, 2, 3}, reflect.TypeOf(""), []string{"1", "2", "3"}},
+		{[]int{1, 2, 3}, nil, []interface{}{"1", "2", "3"}},
+	} {
+		xtesting.Equal(t, MapG(tc.give, toStr, tc.giveType), tc.want)
+	}
+	xtesting.Panic(t, func() { MapG([]int{1}, toStr, reflect.TypeOf(0)) })
+	xtesting.Panic(t, func() { MapG(nil, toStr, nil) })
+}
+
+func TestFlatMap(t *testing.T) {
+	repeat := func(i interface{}) []interface{} {
+		out := make([]interface{}, 0)
+		for j := 0; j < i.(int); j++ {
+			out = append(out, i)
+		}
+		return out
+	}
+	xtesting.Equal(t, FlatMap(nil, repeat), []interface{}{})
+	xtesting.Equal(t, FlatMap([]interface{}{0, 1, 2, 3}, repeat), []interface{}{1, 2, 2, 3, 3, 3})
+	xtesting.Panic(t, func() { FlatMap([]interface{}{}, nil) })
+
+	split := func(i interface{}) interface{} { return []byte(i.(string)) }
+	xtesting.Equal(t, FlatMapG([]string{}, split, reflect.TypeOf(byte(0))), []byte{})
+	xtesting.Equal(t, FlatMapG([]string{"ab", "", "c"}, split, reflect.TypeOf(byte(0))), []byte{'a', 'b', 'c'})
+	xtesting.Equal(t, FlatMapG([]string{"ab"}, split, nil), []interface{}{byte('a'), byte('b')})
+	xtesting.Panic(t, func() { FlatMapG([]string{"a"}, func(interface{}) interface{} { return 0 }, nil) })
+}
+
+func TestFilterAndPartition(t *testing.T) {
+	even := func(i interface{}) bool { return i.(int)%2 == 0 }
+
+	for _, tc := range []struct {
+		give          []interface{}
+		wantMatched   []interface{}
+		wantUnmatched []interface{}
+	}{
+		{nil, []interface{}{}, []interface{}{}},
+		{[]interface{}{1, 3}, []interface{}{}, []interface{}{1, 3}},
+		{[]interface{}{2, 4}, []interface{}{2, 4}, []interface{}{}},
+		{[]interface{}{1, 2, 3, 4, 5, 6}, []interface{}{2, 4, 6}, []interface{}{1, 3, 5}},
+	} {
+		xtesting.Equal(t, Filter(tc.give, even), tc.wantMatched)
+		matched, unmatched := Partition(tc.give, even)
+		xtesting.Equal(t, matched, tc.wantMatched)
+		xtesting.Equal(t, unmatched, tc.wantUnmatched)
+	}
+
+	for _, tc := range []struct {
+		give          []int
+		wantMatched   []int
+		wantUnmatched []int
+	}{
+		{nil, []int{}, []int{}},
+		{[]int{1, 3}, []int{}, []int{1, 3}},
+		{[]int{2, 4}, []int{2, 4}, []int{}},
+		{[]int{1, 2, 3, 4, 5, 6}, []int{2, 4, 6}, []int{1, 3, 5}},
+	} {
+		xtesting.Equal(t, FilterG(tc.give, even), tc.wantMatched)
+		matched, unmatched := PartitionG(tc.give, even)
+		xtesting.Equal(t, matched, tc.wantMatched)
+		xtesting.Equal(t, unmatched, tc.wantUnmatched)
+	}
+
+	xtesting.Panic(t, func() { Filter([]interface{}{}, nil) })
+	xtesting.Panic(t, func() { PartitionG([]int{}, nil) })
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(acc, item interface{}) interface{} { return acc.(int) + item.(int) }
+	concat := func(acc, item interface{}) interface{} { return acc.(string) + strconv.Itoa(item.(int)) }
+
+	xtesting.Equal(t, Reduce(nil, 0, sum), 0)
+	xtesting.Equal(t, Reduce([]interface{}{1, 2, 3}, 0, sum), 6)
+	xtesting.Equal(t, Reduce([]interface{}{1, 2, 3}, "", concat), "123")
+	xtesting.Equal(t, ReduceG([]int{}, 10, sum), 10)
+	xtesting.Equal(t, ReduceG([]int{1, 2, 3}, 10, sum), 16)
+	xtesting.Equal(t, ReduceG([]int{3, 2, 1}, "0", concat), "0321")
+	xtesting.Panic(t, func() { Reduce([]interface{}{}, 0, nil) })
+}
+
+func TestGroupBy(t *testing.T) {
+	mod3 := func(i interface{}) interface{} { return i.(int) % 3 }
+
+	keys, groups := GroupBy(nil, mod3)
+	xtesting.Equal(t, keys, []interface{}{})
+	xtesting.Equal(t, groups, [][]interface{}{})
+	keys, groups = GroupBy([]interface{}{4, 1, 2, 3, 5, 6, 7}, mod3)
+	xtesting.Equal(t, keys, []interface{}{1, 2, 0})
+	xtesting.Equal(t, groups, [][]interface{}{{4, 1, 7}, {2, 5}, {3, 6}})
+
+	keys, groupsG := GroupByG([]int{}, mod3)
+	xtesting.Equal(t, keys, []interface{}{})
+	xtesting.Equal(t, groupsG, [][]int{})
+	keys, groupsG = GroupByG([]int{4, 1, 2, 3, 5, 6, 7}, mod3)
+	xtesting.Equal(t, keys, []interface{}{1, 2, 0})
+	xtesting.Equal(t, groupsG, [][]int{{4, 1, 7}, {2, 5}, {3, 6}})
+
+	xtesting.Panic(t, func() { GroupBy([]interface{}{}, nil) })
+	xtesting.Panic(t, func() { GroupBy([]interface{}{1}, func(interface{}) interface{} { return []int{} }) })
+}
+
+func TestChunkAndWindow(t *testing.T) {
+	for _, tc := range []struct {
+		give       []interface{}
+		giveSize   int
+		wantChunk  [][]interface{}
+		wantWindow [][]interface{}
+	}{
+		{nil, 1, [][]interface{}{}, [][]interface{}{}},
+		{[]interface{}{1, 2, 3}, 1, [][]interface{}{{1}, {2}, {3}}, [][]interface{}{{1}, {2}, {3}}},
+		{[]interface{}{1, 2, 3}, 2, [][]interface{}{{1, 2}, {3}}, [][]interface{}{{1, 2}, {2, 3}}},
+		{[]interface{}{1, 2, 3}, 3, [][]interface{}{{1, 2, 3}}, [][]interface{}{{1, 2, 3}}},
+		{[]interface{}{1, 2, 3}, 4, [][]interface{}{{1, 2, 3}}, [][]interface{}{}},
+	} {
+		xtesting.Equal(t, Chunk(tc.give, tc.giveSize), tc.wantChunk)
+		xtesting.Equal(t, Window(tc.give, tc.giveSize), tc.wantWindow)
+	}
+
+	for _, tc := range []struct {
+		give       []int
+		giveSize   int
+		wantChunk  [][]int
+		wantWindow [][]int
+	}{
+		{nil, 1, [][]int{}, [][]int{}},
+		{[]int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}, [][]int{{1}, {2}, {3}}},
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}, [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}},
+		{[]int{1, 2, 3}, 4, [][]int{{1, 2, 3}}, [][]int{}},
+	} {
+		xtesting.Equal(t, ChunkG(tc.give, tc.giveSize), tc.wantChunk)
+		xtesting.Equal(t, WindowG(tc.give, tc.giveSize), tc.wantWindow)
+	}
+
+	xtesting.Panic(t, func() { Chunk([]interface{}{}, 0) })
+	xtesting.Panic(t, func() { WindowG([]int{}, -1) })
+
+	// chunks must not share the underlying array with the origin slice
+	s := []int{1, 2, 3, 4}
+	chunks := ChunkG(s, 2).([][]int)
+	chunks[0][0] = 0
+	xtesting.Equal(t, s, []int{1, 2, 3, 4})
+}
+
+func TestZipAndUnzip(t *testing.T) {
+	xtesting.Equal(t, Zip(nil, nil), []Pair{})
+	xtesting.Equal(t, Zip([]interface{}{1, 2, 3}, []interface{}{"1", "2"}), []Pair{{1, "1"}, {2, "2"}})
+	xtesting.Equal(t, ZipG([]int{1, 2}, []string{"1", "2", "3"}), []Pair{{1, "1"}, {2, "2"}})
+	xtesting.Panic(t, func() { ZipG([]int{}, nil) })
+
+	s1, s2 := Unzip(nil)
+	xtesting.Equal(t, s1, []interface{}{})
+	xtesting.Equal(t, s2, []interface{}{})
+	s1, s2 = Unzip([]Pair{{1, "1"}, {2, nil}})
+	xtesting.Equal(t, s1, []interface{}{1, 2})
+	xtesting.Equal(t, s2, []interface{}{"1", nil})
+	g1, g2 := UnzipG([]Pair{{1, "1"}, {2, nil}}, reflect.TypeOf(0), reflect.TypeOf(""))
+	xtesting.Equal(t, g1, []int{1, 2})
+	xtesting.Equal(t, g2, []string{"1", ""})
+	g1, g2 = UnzipG(ZipG([]int{1, 2}, []string{"1", "2"}), reflect.TypeOf(0), nil)
+	xtesting.Equal(t, g1, []int{1, 2})
+	xtesting.Equal(t, g2, []interface{}{"1", "2"})
+	xtesting.Panic(t, func() { UnzipG([]Pair{{1, "1"}}, reflect.TypeOf(""), nil) })
+}
+
+func TestDistinctBy(t *testing.T) {
+	mod3 := func(i interface{}) interface{} { return i.(int) % 3 }
+
+	xtesting.Equal(t, DistinctBy(nil, mod3), []interface{}{})
+	xtesting.Equal(t, DistinctBy([]interface{}{4, 1, 2, 3, 5, 6, 7}, mod3), []interface{}{4, 2, 3})
+	xtesting.Equal(t, DistinctByG([]int{}, mod3), []int{})
+	xtesting.Equal(t, DistinctByG([]int{4, 1, 2, 3, 5, 6, 7}, mod3), []int{4, 2, 3})
+	xtesting.Panic(t, func() { DistinctBy([]interface{}{}, nil) })
+}
+
+func TestTakeAndDrop(t *testing.T) {
+	s1 := []interface{}{1, 2, 3, 4}
+	s2 := []int{1, 2, 3, 4}
+
+	for _, tc := range []struct {
+		giveN    int
+		wantTake []int
+		wantDrop []int
+	}{
+		{-1, []int{}, []int{1, 2, 3, 4}},
+		{0, []int{}, []int{1, 2, 3, 4}},
+		{1, []int{1}, []int{2, 3, 4}},
+		{3, []int{1, 2, 3}, []int{4}},
+		{4, []int{1, 2, 3, 4}, []int{}},
+		{5, []int{1, 2, 3, 4}, []int{}},
+	} {
+		xtesting.Equal(t, TakeG(s2, tc.giveN), tc.wantTake)
+		xtesting.Equal(t, DropG(s2, tc.giveN), tc.wantDrop)
+		xtesting.Equal(t, Take(s1, tc.giveN), MapG(tc.wantTake, func(i interface{}) interface{} { return i }, nil))
+		xtesting.Equal(t, Drop(s1, tc.giveN), MapG(tc.wantDrop, func(i interface{}) interface{} { return i }, nil))
+	}
+	xtesting.Equal(t, Take(nil, 1), []interface{}{})
+	xtesting.Equal(t, DropG([]int(nil), 1), []int{})
+
+	less3 := func(i interface{}) bool { return i.(int) < 3 }
+	xtesting.Equal(t, TakeWhile(nil, less3), []interface{}{})
+	xtesting.Equal(t, TakeWhile([]interface{}{1, 2, 3, 1}, less3), []interface{}{1, 2})
+	xtesting.Equal(t, DropWhile([]interface{}{1, 2, 3, 1}, less3), []interface{}{3, 1})
+	xtesting.Equal(t, TakeWhileG([]int{3, 1}, less3), []int{})
+	xtesting.Equal(t, DropWhileG([]int{3, 1}, less3), []int{3, 1})
+	xtesting.Equal(t, TakeWhileG([]int{1, 2}, less3), []int{1, 2})
+	xtesting.Equal(t, DropWhileG([]int{1, 2}, less3), []int{})
+	xtesting.Panic(t, func() { TakeWhile([]interface{}{}, nil) })
+	xtesting.Panic(t, func() { DropWhileG([]int{}, nil) })
+}
@@ -2,13 +2,11 @@ package xstring
 
 import (
 	"bytes"
-	"math/rand"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 	"unicode"
-	"unsafe"
 )
 
 // Capitalize capitalizes the first letter of the whole string.
@@ -83,6 +81,10 @@ var defaultSplitters = []string{CaseSplitter, "_", "-", "."}
 
 // SplitToWords splits a single string to a word array using default and given word separator. Default separators are [ \t\n\v\f\r\x85\xA0\u3000] (blank) and
 // [_-.], you can set the seps parameter to use different word separators (but blank characters are just treated as word separator).
+//
+// Note that when CaseSplitter is used, acronyms are also detected, such as "HTTPServerID" to ["HTTP", "Server", "ID"]. Here an
+// acronym has at least two uppercase letters, and is ended before the uppercase letter which is followed by at least two
+// lowercase letters, so "IPv4", "URLs" and "AAaa" are kept. And digits are kept with the previous word, such as "Base64Encode" to ["Base64", "Encode"].
 func SplitToWords(s string, seps ...string) []string { // caseHelper
 	// separators
 	if len(seps) == 0 {
@@ -108,15 +110,17 @@ func SplitToWords(s string, seps ...string) []string { // caseHelper
 
 	// split
 	if splitCase {
+		runes := []rune(s)
 		sb := strings.Builder{}
-		lastLower := false
-		for i, r := range s {
-			currLower := !unicode.IsUpper(r)
-			if i > 0 && !currLower && lastLower {
-				sb.WriteRune(' ') // split by case
+		for i, r := range runes {
+			if i > 0 && unicode.IsUpper(r) {
+				if !unicode.IsUpper(runes[i-1]) {
+					sb.WriteRune(' ') // split by case, such as "helloWorld" and "ipv4Address"
+				} else if i >= 2 && unicode.IsUpper(runes[i-2]) && i+2 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsLower(runes[i+2]) {
+					sb.WriteRune(' ') // split by acronym, such as "HTTPServer", but keep "IPv4", "URLs" and "AAaa"
+				}
 			}
 			sb.WriteRune(r)
-			lastLower = currLower
 		}
 		s = sb.String()
 	}
@@ -126,28 +130,94 @@ func SplitToWords(s string, seps ...string) []string { // caseHelper
 	return words
 }
 
-// PascalCase rewrites string in pascal case using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
-func PascalCase(s string, extraSeps ...string) string {
+// GolintInitialisms is the initialisms list used by golint, can be used in CaseConverter.
+// See https://github.com/golang/lint/blob/master/lint.go for details.
+var GolintInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "LHS", "QPS",
+	"RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8",
+	"VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// CaseConverter represents a case converter with options, which is used in PascalCase, CamelCase, TrainCase and TitleCase.
+// The package-level functions use the zero value, that is no initialism is set.
+//
+// Example:
+// 	c := &CaseConverter{Initialisms: GolintInitialisms}
+// 	c.PascalCase("user_id")  // => UserID
+// 	c.CamelCase("json_data") // => jsonData
+type CaseConverter struct {
+	// Initialisms represents the initialisms (case-insensitive) which will be written in uppercase, such as "user_id" to
+	// "UserID" when "ID" is set. Note that the first word in CamelCase is always written in lowercase.
+	Initialisms []string
+}
+
+// GolintCaseConverter is the CaseConverter using GolintInitialisms.
+var GolintCaseConverter = &CaseConverter{Initialisms: GolintInitialisms}
+
+// defaultCaseConverter is the CaseConverter used by the package-level functions.
+var defaultCaseConverter = &CaseConverter{}
+
+// capitalizeWord capitalizes the given word, or writes it in uppercase if it is an initialism.
+func (c *CaseConverter) capitalizeWord(word string) string {
+	for _, i := range c.Initialisms {
+		if strings.EqualFold(i, word) {
+			return strings.ToUpper(word)
+		}
+	}
+	return Capitalize(word)
+}
+
+// PascalCase rewrites string in pascal case, and the words in Initialisms are written in uppercase, such as "user_id" to "UserID".
+func (c *CaseConverter) PascalCase(s string, extraSeps ...string) string {
 	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
 	for i, word := range wordArray {
-		wordArray[i] = Capitalize(word)
+		wordArray[i] = c.capitalizeWord(word)
 	}
 	return strings.Join(wordArray, "")
 }
 
-// CamelCase rewrites string in camel case using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
-func CamelCase(s string, extraSeps ...string) string {
+// CamelCase rewrites string in camel case, and the words in Initialisms except the first one are written in uppercase, such as
+// "user_id" to "userID" and "id_card" to "idCard".
+func (c *CaseConverter) CamelCase(s string, extraSeps ...string) string {
 	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
 	for i, word := range wordArray {
 		if i == 0 {
 			wordArray[i] = strings.ToLower(word)
 		} else {
-			wordArray[i] = Capitalize(word)
+			wordArray[i] = c.capitalizeWord(word)
 		}
 	}
 	return strings.Join(wordArray, "")
 }
 
+// TrainCase rewrites string in train case, and the words in Initialisms are written in uppercase, such as "user_id" to "User-ID".
+func (c *CaseConverter) TrainCase(s string, extraSeps ...string) string {
+	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
+	for i, word := range wordArray {
+		wordArray[i] = c.capitalizeWord(word)
+	}
+	return strings.Join(wordArray, "-")
+}
+
+// TitleCase rewrites string in title case, and the words in Initialisms are written in uppercase, such as "user_id" to "User ID".
+func (c *CaseConverter) TitleCase(s string, extraSeps ...string) string {
+	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
+	for i, word := range wordArray {
+		wordArray[i] = c.capitalizeWord(word)
+	}
+	return strings.Join(wordArray, " ")
+}
+
+// PascalCase rewrites string in pascal case using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+func PascalCase(s string, extraSeps ...string) string {
+	return defaultCaseConverter.PascalCase(s, extraSeps...)
+}
+
+// CamelCase rewrites string in camel case using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+func CamelCase(s string, extraSeps ...string) string {
+	return defaultCaseConverter.CamelCase(s, extraSeps...)
+}
+
 // SnakeCase rewrites string in snake case using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
 func SnakeCase(s string, extraSeps ...string) string {
 	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
@@ -157,7 +227,17 @@ func SnakeCase(s string, extraSeps ...string) string {
 	return strings.Join(wordArray, "_")
 }
 
+// ScreamingSnakeCase rewrites string in screaming snake case, such as "HELLO_WORLD", using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+func ScreamingSnakeCase(s string, extraSeps ...string) string {
+	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
+	for i, word := range wordArray {
+		wordArray[i] = strings.ToUpper(word)
+	}
+	return strings.Join(wordArray, "_")
+}
+
 // KebabCase rewrites string in kebab case using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+// Note that non-ASCII characters and punctuations are kept, please use Slugify to generate URL slugs.
 func KebabCase(s string, extraSeps ...string) string {
 	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
 	for i, word := range wordArray {
@@ -166,7 +246,27 @@ func KebabCase(s string, extraSeps ...string) string {
 	return strings.Join(wordArray, "-")
 }
 
+// TrainCase rewrites string in train case, such as "Hello-World", using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+func TrainCase(s string, extraSeps ...string) string {
+	return defaultCaseConverter.TrainCase(s, extraSeps...)
+}
+
+// DotCase rewrites string in dot case, such as "hello.world", using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+func DotCase(s string, extraSeps ...string) string {
+	wordArray := SplitToWords(s, append(defaultSplitters, extraSeps...)...)
+	for i, word := range wordArray {
+		wordArray[i] = strings.ToLower(word)
+	}
+	return strings.Join(wordArray, ".")
+}
+
+// TitleCase rewrites string in title case, such as "Hello World", using word separator. By default, [ \t\n\v\f\r\x85\xA0\u3000] and [_-.] are treated as word separator.
+func TitleCase(s string, extraSeps ...string) string {
+	return defaultCaseConverter.TitleCase(s, extraSeps...)
+}
+
 // TimeUUID creates a uuid from given time. If the count is larger than 23, the remaining bits will be filled by rand numbers.
+// Note that this is not a RFC 9562 UUID and is not collision-resistant, please use NewUUIDv7 or NewULID instead.
 func TimeUUID(t time.Time, count int) string {
 	layoutWithNanosecond := "20060102150405.000000000"
 	uuid := t.Format(layoutWithNanosecond)
@@ -179,55 +279,6 @@ func TimeUUID(t time.Time, count int) string {
 	return uuid + RandNumberString(count-l)
 }
 
-// RandString generates a string by given rune slice in random order.
-func RandString(count int, runes []rune) string {
-	rand.Seed(time.Now().UnixNano())
-	b := make([]rune, count)
-	for i := range b {
-		b[i] = runes[rand.Intn(len(runes))]
-	}
-	return string(b)
-}
-
-var (
-	capitalLetterRunes         = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
-	lowercaseLetterRunes       = []rune("abcdefghijklmnopqrstuvwxyz")
-	allcaseLetterRunes         = append(capitalLetterRunes, lowercaseLetterRunes...)
-	numberRunes                = []rune("0123456789")
-	capitalLetterNumberRunes   = append(capitalLetterRunes, numberRunes...)
-	lowercaseLetterNumberRunes = append(lowercaseLetterRunes, numberRunes...)
-)
-
-// RandCapitalLetterString generates a random string combined by capital letters, that is ABCDEFGHIJKLMNOPQRSTUVWXYZ.
-func RandCapitalLetterString(count int) string {
-	return RandString(count, capitalLetterRunes)
-}
-
-// RandLowercaseLetterString generates a random string combined by lowercase letters, that is abcdefghijklmnopqrstuvwxyz.
-func RandLowercaseLetterString(count int) string {
-	return RandString(count, lowercaseLetterRunes)
-}
-
-// RandLetterString generates a random string combined by allcase letters, that is ABCDEFGHIJKLMNOPQRSTUVWXYZ + abcdefghijklmnopqrstuvwxyz.
-func RandLetterString(count int) string {
-	return RandString(count, allcaseLetterRunes)
-}
-
-// RandNumberString generates a random string combined by numbers, that is 0123456789.
-func RandNumberString(count int) string {
-	return RandString(count, numberRunes)
-}
-
-// RandCapitalLetterNumberString generates a random string combined by capital letters and numbers, that is ABCDEFGHIJKLMNOPQRSTUVWXYZ + 0123456789.
-func RandCapitalLetterNumberString(count int) string {
-	return RandString(count, capitalLetterNumberRunes)
-}
-
-// RandLowercaseLetterNumberString generates a random string combined by lowercase letters and numbers, that is abcdefghijklmnopqrstuvwxyz + 0123456789.
-func RandLowercaseLetterNumberString(count int) string {
-	return RandString(count, lowercaseLetterNumberRunes)
-}
-
 // MaskToken masks a token string and returns the result, using given mask rune and indices for mask characters, this function also supports minus index.
 func MaskToken(s string, mask rune, indices ...int) string {
 	return coreMaskToken(s, mask, true, indices...)
@@ -292,25 +343,6 @@ func coreMaskToken(s string, mask rune, usedToMask bool, indices ...int) string
 	return sb.String()
 }
 
-// FastStob fast casts string to []byte in an unsafe ways.
-func FastStob(s string) []byte {
-	if s == "" {
-		return []byte{}
-	}
-	return *(*[]byte)(unsafe.Pointer(&struct {
-		string
-		Cap int
-	}{s, len(s)}))
-}
-
-// FastBtos fast casts []byte to string in an unsafe ways.
-func FastBtos(bs []byte) string {
-	if bs == nil || len(bs) == 0 {
-		return ""
-	}
-	return *(*string)(unsafe.Pointer(&bs))
-}
-
 const (
 	// utf8BomString is UTF8 BOM character in string, U+FEFF, 0xEF 0xBB 0xBF.
 	utf8BomString = "\xef\xbb\xbf"
@@ -335,6 +367,7 @@ func TrimUTF8Bom(s string) string {
 
 // TrimUTF8BomBytes trims BOM (byte order mark, U+FEFF, that is 0xEF 0xBB 0xBF in UTF-8) from a bytes.
 // See https://en.wikipedia.org/wiki/Byte_order_mark#Byte_order_marks_by_encoding and https://www.compart.com/en/unicode/U+FEFF for details.
+// Please use DetectBOM or ToUTF8 to handle UTF-16 and UTF-32 BOM.
 func TrimUTF8BomBytes(bs []byte) []byte {
 	return bytes.TrimPrefix(bs, utf8BomBytes)
 }
@@ -352,7 +385,8 @@ func TrimUTF8ReplacementBytes(bs []byte) []byte {
 }
 
 // EncodeUrlValues encodes the values (see url.Values) into url encoded form ("bar=baz&foo=quux") sorted by key with escape.
-// The escapeFunc can be url.QueryEscape, url.PathEscape or the functions you defined, use nil for no escape.
+// The escapeFunc can be url.QueryEscape, url.PathEscape or the functions you defined, use nil for no escape. Please use
+// Query if the order of keys needs to be kept.
 func EncodeUrlValues(values map[string][]string, escapeFunc func(string) string) string {
 	keys := make([]string, 0, len(values))
 	for k := range values {
@@ -383,7 +417,8 @@ func EncodeUrlValues(values map[string][]string, escapeFunc func(string) string)
 	return sb.String()
 }
 
-// PadLeft returns the string with length of totalLength, which is padded by char in left.
+// PadLeft returns the string with length of totalLength, which is padded by char in left. Note that the length is counted
+// in runes, please use PadLeftWidth for display width.
 func PadLeft(s string, char rune, totalLength int) string {
 	l := 0 // length
 	for range s {
@@ -397,7 +432,8 @@ func PadLeft(s string, char rune, totalLength int) string {
 	return sp.String()
 }
 
-// PadRight returns the string with length of totalLength, which is padded by char in right.
+// PadRight returns the string with length of totalLength, which is padded by char in right. Note that the length is counted
+// in runes, please use PadRightWidth for display width.
 func PadRight(s string, char rune, totalLength int) string {
 	l := 0 // length
 	for range s {
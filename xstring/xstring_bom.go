@@ -0,0 +1,333 @@
+package xstring
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// BOMEncoding represents the encoding detected from BOM (byte order mark), is returned by DetectBOM.
+type BOMEncoding uint8
+
+const (
+	// BOMNone means there is no BOM.
+	BOMNone BOMEncoding = iota
+
+	// BOMUTF8 means UTF-8 BOM, that is 0xEF 0xBB 0xBF.
+	BOMUTF8
+
+	// BOMUTF16LE means UTF-16 little endian BOM, that is 0xFF 0xFE.
+	BOMUTF16LE
+
+	// BOMUTF16BE means UTF-16 big endian BOM, that is 0xFE 0xFF.
+	BOMUTF16BE
+
+	// BOMUTF32LE means UTF-32 little endian BOM, that is 0xFF 0xFE 0x00 0x00.
+	BOMUTF32LE
+
+	// BOMUTF32BE means UTF-32 big endian BOM, that is 0x00 0x00 0xFE 0xFF.
+	BOMUTF32BE
+)
+
+var (
+	// _bomBytes represents the BOM bytes of each BOMEncoding, note that UTF-32 must be checked before UTF-16.
+	_bomBytes = [...][]byte{
+		BOMUTF8:    utf8BomBytes,
+		BOMUTF32LE: {0xFF, 0xFE, 0x00, 0x00},
+		BOMUTF32BE: {0x00, 0x00, 0xFE, 0xFF},
+		BOMUTF16LE: {0xFF, 0xFE},
+		BOMUTF16BE: {0xFE, 0xFF},
+	}
+
+	// _bomNames represents the names of each BOMEncoding.
+	_bomNames = [...]string{
+		BOMNone:    "None",
+		BOMUTF8:    "UTF-8",
+		BOMUTF16LE: "UTF-16LE",
+		BOMUTF16BE: "UTF-16BE",
+		BOMUTF32LE: "UTF-32LE",
+		BOMUTF32BE: "UTF-32BE",
+	}
+)
+
+// String returns the name of BOMEncoding, such as "UTF-8" and "UTF-16LE".
+func (b BOMEncoding) String() string {
+	if int(b) < len(_bomNames) {
+		return _bomNames[b]
+	}
+	return "Unknown"
+}
+
+// Bytes returns the BOM bytes of BOMEncoding, returns nil for BOMNone.
+func (b BOMEncoding) Bytes() []byte {
+	if b == BOMNone || int(b) >= len(_bomBytes) {
+		return nil
+	}
+	return append([]byte{}, _bomBytes[b]...)
+}
+
+// byteOrder returns the byte order of UTF-16 and UTF-32 BOMEncoding, returns nil for others.
+func (b BOMEncoding) byteOrder() binary.ByteOrder {
+	switch b {
+	case BOMUTF16LE, BOMUTF32LE:
+		return binary.LittleEndian
+	case BOMUTF16BE, BOMUTF32BE:
+		return binary.BigEndian
+	}
+	return nil
+}
+
+// DetectBOM detects the BOM (byte order mark) from the beginning of given bytes, and returns the BOMEncoding and the length of
+// BOM. Note that UTF-16LE text which starts with U+0000 will be detected as UTF-32LE.
+// See https://en.wikipedia.org/wiki/Byte_order_mark#Byte_order_marks_by_encoding for details.
+func DetectBOM(bs []byte) (BOMEncoding, int) {
+	for _, enc := range []BOMEncoding{BOMUTF8, BOMUTF32LE, BOMUTF32BE, BOMUTF16LE, BOMUTF16BE} {
+		if bytes.HasPrefix(bs, _bomBytes[enc]) {
+			return enc, len(_bomBytes[enc])
+		}
+	}
+	return BOMNone, 0
+}
+
+// BOMStripReader represents an io.Reader which strips the BOM from the wrapped io.Reader, is created by NewBOMStripReader.
+type BOMStripReader struct {
+	r        *bufio.Reader
+	detected bool
+	encoding BOMEncoding
+	err      error
+}
+
+// NewBOMStripReader creates a BOMStripReader which wraps given io.Reader, the BOM will be detected and stripped lazily in
+// the first Read or Encoding call.
+func NewBOMStripReader(r io.Reader) *BOMStripReader {
+	return &BOMStripReader{r: bufio.NewReader(r)}
+}
+
+// detect detects and strips the BOM only once.
+func (b *BOMStripReader) detect() {
+	if b.detected {
+		return
+	}
+	b.detected = true
+	head, err := b.r.Peek(4) // may be shorter than 4 bytes
+	if err != nil && err != io.EOF {
+		b.err = err
+		return
+	}
+	var n int
+	b.encoding, n = DetectBOM(head)
+	_, _ = b.r.Discard(n)
+}
+
+// Encoding returns the detected BOMEncoding, and returns the error occurred when reading BOM.
+func (b *BOMStripReader) Encoding() (BOMEncoding, error) {
+	b.detect()
+	return b.encoding, b.err
+}
+
+// Read implements io.Reader, it reads data after the BOM from the wrapped io.Reader.
+func (b *BOMStripReader) Read(p []byte) (int, error) {
+	b.detect()
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.r.Read(p)
+}
+
+// utf8TranscodeReader represents an io.Reader which transcodes UTF-16 and UTF-32 data to UTF-8, is created by
+// NewUTF8TranscodeReader.
+type utf8TranscodeReader struct {
+	src     *BOMStripReader
+	buf     [utf8.UTFMax]byte
+	pending []byte
+	err     error
+}
+
+// NewUTF8TranscodeReader creates an io.Reader which strips the BOM of given io.Reader, and transcodes the UTF-16 or UTF-32
+// data to UTF-8 according to the BOM. Note that the data without BOM or with UTF-8 BOM is returned as it is, and invalid
+// code units will be replaced with U+FFFD.
+//
+// Example:
+// 	r := NewUTF8TranscodeReader(bytes.NewReader([]byte{0xFF, 0xFE, 'a', 0x00, 0x2D, 0x4E}))
+// 	bs, _ := ioutil.ReadAll(r) // => a中
+func NewUTF8TranscodeReader(r io.Reader) io.Reader {
+	return &utf8TranscodeReader{src: NewBOMStripReader(r)}
+}
+
+// Read implements io.Reader.
+func (t *utf8TranscodeReader) Read(p []byte) (int, error) {
+	enc, err := t.src.Encoding()
+	if err != nil {
+		return 0, err
+	}
+	order := enc.byteOrder()
+	if order == nil {
+		return t.src.Read(p)
+	}
+	unitSize := 2
+	if enc == BOMUTF32LE || enc == BOMUTF32BE {
+		unitSize = 4
+	}
+
+	n := 0
+	for n < len(p) {
+		if len(t.pending) > 0 {
+			c := copy(p[n:], t.pending)
+			t.pending = t.pending[c:]
+			n += c
+			continue
+		}
+		if t.err != nil || (n > 0 && t.src.r.Buffered() < unitSize) {
+			break // avoid blocking when some data has been read
+		}
+		var r rune
+		if unitSize == 2 {
+			r, t.err = readUTF16Rune(t.src.r, order)
+		} else {
+			r, t.err = readUTF32Rune(t.src.r, order)
+		}
+		if t.err == nil {
+			t.pending = t.buf[:utf8.EncodeRune(t.buf[:], r)]
+		}
+	}
+	if n == 0 && t.err != nil {
+		return 0, t.err
+	}
+	return n, nil
+}
+
+// readUTF16Rune reads a rune from UTF-16 data, returns io.EOF when no data and io.ErrUnexpectedEOF when the data is truncated.
+func readUTF16Rune(br *bufio.Reader, order binary.ByteOrder) (rune, error) {
+	var unit [2]byte
+	if _, err := io.ReadFull(br, unit[:]); err != nil {
+		return 0, err
+	}
+	r1 := rune(order.Uint16(unit[:]))
+	if !utf16.IsSurrogate(r1) {
+		return r1, nil
+	}
+	if next, err := br.Peek(2); err == nil {
+		if r := utf16.DecodeRune(r1, rune(order.Uint16(next))); r != utf8.RuneError {
+			_, _ = br.Discard(2)
+			return r, nil
+		}
+	}
+	return utf8.RuneError, nil
+}
+
+// readUTF32Rune reads a rune from UTF-32 data, returns io.EOF when no data and io.ErrUnexpectedEOF when the data is truncated.
+func readUTF32Rune(br *bufio.Reader, order binary.ByteOrder) (rune, error) {
+	var unit [4]byte
+	if _, err := io.ReadFull(br, unit[:]); err != nil {
+		return 0, err
+	}
+	return decodeUTF32Unit(order.Uint32(unit[:])), nil
+}
+
+// decodeUTF32Unit decodes given UTF-32 code unit, and returns U+FFFD if it is invalid.
+func decodeUTF32Unit(u uint32) rune {
+	if u > utf8.MaxRune || !utf8.ValidRune(rune(u)) {
+		return utf8.RuneError
+	}
+	return rune(u)
+}
+
+var (
+	errTruncatedUTF16 = errors.New("xstring: truncated UTF-16 data")
+	errTruncatedUTF32 = errors.New("xstring: truncated UTF-32 data")
+)
+
+// DecodeUTF16 decodes given UTF-16 data (without BOM) to UTF-8 string using given byte order, such as binary.LittleEndian.
+// Note that unpaired surrogates will be replaced with U+FFFD, and an error will be returned if the length of data is odd.
+func DecodeUTF16(bs []byte, order binary.ByteOrder) (string, error) {
+	if len(bs)%2 != 0 {
+		return "", errTruncatedUTF16
+	}
+	units := make([]uint16, len(bs)/2)
+	for i := range units {
+		units[i] = order.Uint16(bs[i*2:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// DecodeUTF32 decodes given UTF-32 data (without BOM) to UTF-8 string using given byte order, such as binary.BigEndian.
+// Note that invalid code points will be replaced with U+FFFD, and an error will be returned if the length of data is not a
+// multiple of 4.
+func DecodeUTF32(bs []byte, order binary.ByteOrder) (string, error) {
+	if len(bs)%4 != 0 {
+		return "", errTruncatedUTF32
+	}
+	runes := make([]rune, len(bs)/4)
+	for i := range runes {
+		runes[i] = decodeUTF32Unit(order.Uint32(bs[i*4:]))
+	}
+	return string(runes), nil
+}
+
+// ToUTF8 detects the BOM of given bytes and converts the data to UTF-8 string without BOM. Here UTF-16 and UTF-32 data are
+// transcoded by DecodeUTF16 and DecodeUTF32, and the data without BOM is treated as UTF-8 and will be validated by
+// ValidateUTF8.
+//
+// Example:
+// 	ToUTF8([]byte{0xFE, 0xFF, 0x00, 'a', 0x4E, 0x2D}) // => a中
+// 	ToUTF8([]byte{0xEF, 0xBB, 0xBF, 'a'})            // => a
+// 	ToUTF8([]byte{'a', 0xFF})                        // => error, *InvalidUTF8Error{Offset: 1}
+func ToUTF8(bs []byte) (string, error) {
+	enc, n := DetectBOM(bs)
+	bs = bs[n:]
+	switch enc {
+	case BOMUTF16LE, BOMUTF16BE:
+		return DecodeUTF16(bs, enc.byteOrder())
+	case BOMUTF32LE, BOMUTF32BE:
+		return DecodeUTF32(bs, enc.byteOrder())
+	}
+	if err := ValidateUTF8(bs); err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// InvalidUTF8Error represents an error that the data contains invalid UTF-8 sequence, is returned by ValidateUTF8 and ToUTF8.
+type InvalidUTF8Error struct {
+	// Offset represents the byte offset of the first invalid sequence.
+	Offset int
+}
+
+// Error returns the formatted InvalidUTF8Error.
+func (i *InvalidUTF8Error) Error() string {
+	return fmt.Sprintf("xstring: invalid UTF-8 sequence at offset %d", i.Offset)
+}
+
+// InvalidUTF8Index returns the byte offset of the first invalid UTF-8 sequence in given bytes, returns -1 if the bytes is
+// valid UTF-8 encoded. Also see utf8.Valid.
+func InvalidUTF8Index(bs []byte) int {
+	if utf8.Valid(bs) {
+		return -1
+	}
+	for i := 0; i < len(bs); {
+		if bs[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(bs[i:])
+		if r == utf8.RuneError && size == 1 {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
+
+// ValidateUTF8 validates given bytes is UTF-8 encoded, and returns *InvalidUTF8Error with the offset of the first invalid
+// sequence if not.
+func ValidateUTF8(bs []byte) error {
+	if idx := InvalidUTF8Index(bs); idx != -1 {
+		return &InvalidUTF8Error{Offset: idx}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package xstring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDetectBOM(t *testing.T) {
+	for _, tc := range []struct {
+		give       []byte
+		want       BOMEncoding
+		wantLength int
+		wantName   string
+	}{
+		{nil, BOMNone, 0, "None"},
+		{[]byte("abc"), BOMNone, 0, "None"},
+		{[]byte{0xEF, 0xBB}, BOMNone, 0, "None"},
+		{[]byte{0xEF, 0xBB, 0xBF, 'a'}, BOMUTF8, 3, "UTF-8"},
+		{[]byte{0xFF, 0xFE, 'a', 0x00}, BOMUTF16LE, 2, "UTF-16LE"},
+		{[]byte{0xFE, 0xFF, 0x00, 'a'}, BOMUTF16BE, 2, "UTF-16BE"},
+		{[]byte{0xFF, 0xFE, 0x00, 0x00, 'a', 0, 0, 0}, BOMUTF32LE, 4, "UTF-32LE"},
+		{[]byte{0x00, 0x00, 0xFE, 0xFF, 0, 0, 0, 'a'}, BOMUTF32BE, 4, "UTF-32BE"},
+		{[]byte{0x00, 0x00, 0xFE}, BOMNone, 0, "None"},
+	} {
+		enc, n := DetectBOM(tc.give)
+		xtesting.Equal(t, enc, tc.want)
+		xtesting.Equal(t, n, tc.wantLength)
+		xtesting.Equal(t, enc.String(), tc.wantName)
+		if tc.want != BOMNone {
+			xtesting.Equal(t, enc.Bytes(), tc.give[:n])
+		}
+	}
+	xtesting.Nil(t, BOMNone.Bytes())
+	xtesting.Nil(t, BOMEncoding(255).Bytes())
+	xtesting.Equal(t, BOMEncoding(255).String(), "Unknown")
+	bs := BOMUTF8.Bytes()
+	bs[0] = 0
+	xtesting.Equal(t, BOMUTF8.Bytes(), []byte{0xEF, 0xBB, 0xBF})
+}
+
+type testErrorReader struct{ err error }
+
+func (r *testErrorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestBOMStripReader(t *testing.T) {
+	for _, tc := range []struct {
+		give     []byte
+		want     []byte
+		wantEnc  BOMEncoding
+		wantRead bool
+	}{
+		{[]byte{}, []byte{}, BOMNone, true},
+		{[]byte("a"), []byte("a"), BOMNone, true},
+		{[]byte("abcdef"), []byte("abcdef"), BOMNone, true},
+		{[]byte{0xEF, 0xBB, 0xBF}, []byte{}, BOMUTF8, true},
+		{[]byte{0xEF, 0xBB, 0xBF, 'a', ',', 'b'}, []byte("a,b"), BOMUTF8, false},
+		{[]byte{0xFF, 0xFE, 'a', 0x00}, []byte{'a', 0x00}, BOMUTF16LE, false},
+	} {
+		r := NewBOMStripReader(iotest.OneByteReader(bytes.NewReader(tc.give)))
+		if !tc.wantRead {
+			enc, err := r.Encoding()
+			xtesting.Nil(t, err)
+			xtesting.Equal(t, enc, tc.wantEnc)
+		}
+		bs, err := ioutil.ReadAll(r)
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, bs, tc.want)
+		enc, _ := r.Encoding()
+		xtesting.Equal(t, enc, tc.wantEnc)
+	}
+
+	testErr := errors.New("test")
+	r := NewBOMStripReader(&testErrorReader{err: testErr})
+	_, err := r.Encoding()
+	xtesting.Equal(t, err, testErr)
+	_, err = r.Read(make([]byte, 1))
+	xtesting.Equal(t, err, testErr)
+}
+
+func TestUTF8TranscodeReader(t *testing.T) {
+	for _, tc := range []struct {
+		give    []byte
+		want    string
+		wantErr error
+	}{
+		{[]byte{}, "", nil},
+		{[]byte("a,中"), "a,中", nil},
+		{[]byte{0xEF, 0xBB, 0xBF, 'a', ',', 'b'}, "a,b", nil},
+		{[]byte{0xFF, 0xFE}, "", nil},
+		{[]byte{0xFF, 0xFE, 'a', 0x00, ',', 0x00, 0x2D, 0x4E, 0x3D, 0xD8, 0x00, 0xDE}, "a,中😀", nil},
+		{[]byte{0xFE, 0xFF, 0x00, 'a', 0x00, ',', 0x4E, 0x2D, 0xD8, 0x3D, 0xDE, 0x00}, "a,中😀", nil},
+		{[]byte{0xFE, 0xFF, 0xD8, 0x3D, 0x00, 'a', 0xDE, 0x00}, "�a�", nil},
+		{[]byte{0xFE, 0xFF, 0xD8, 0x3D}, "�", nil},
+		{[]byte{0xFE, 0xFF, 0x00, 'a', 0x00}, "a", io.ErrUnexpectedEOF},
+		{[]byte{0xFF, 0xFE, 0x00, 0x00, 'a', 0, 0, 0, 0x2D, 0x4E, 0, 0, 0x00, 0xF6, 0x01, 0x00}, "a中😀", nil},
+		{[]byte{0x00, 0x00, 0xFE, 0xFF, 0, 0, 0, 'a', 0, 0, 0x4E, 0x2D, 0x00, 0x01, 0xF6, 0x00}, "a中😀", nil},
+		{[]byte{0x00, 0x00, 0xFE, 0xFF, 0, 0x11, 0, 0, 0, 0, 0xD8, 0x00, 0xFF, 0xFF, 0xFF, 0xFF}, "���", nil},
+		{[]byte{0x00, 0x00, 0xFE, 0xFF, 0, 0, 0, 'a', 0, 0}, "a", io.ErrUnexpectedEOF},
+	} {
+		for _, oneByte := range []bool{false, true} {
+			var src io.Reader = bytes.NewReader(tc.give)
+			if oneByte {
+				src = iotest.OneByteReader(src)
+			}
+			bs, err := ioutil.ReadAll(NewUTF8TranscodeReader(src))
+			xtesting.Equal(t, err, tc.wantErr)
+			xtesting.Equal(t, string(bs), tc.want)
+		}
+	}
+
+	// small buffer
+	r := NewUTF8TranscodeReader(bytes.NewReader([]byte{0xFF, 0xFE, 0x2D, 0x4E, 0x87, 0x65}))
+	buf := make([]byte, 2)
+	sb := bytes.Buffer{}
+	for {
+		n, err := r.Read(buf)
+		sb.Write(buf[:n])
+		if err != nil {
+			xtesting.Equal(t, err, io.EOF)
+			break
+		}
+	}
+	xtesting.Equal(t, sb.String(), "中文")
+
+	testErr := errors.New("test")
+	_, err := NewUTF8TranscodeReader(&testErrorReader{err: testErr}).Read(buf)
+	xtesting.Equal(t, err, testErr)
+}
+
+func TestDecodeUTF16AndUTF32(t *testing.T) {
+	for _, tc := range []struct {
+		give      []byte
+		giveOrder binary.ByteOrder
+		want      string
+		wantErr   error
+	}{
+		{[]byte{}, binary.LittleEndian, "", nil},
+		{[]byte{'a', 0x00, 0x2D, 0x4E, 0x3D, 0xD8, 0x00, 0xDE}, binary.LittleEndian, "a中😀", nil},
+		{[]byte{0x00, 'a', 0x4E, 0x2D, 0xD8, 0x3D, 0xDE, 0x00}, binary.BigEndian, "a中😀", nil},
+		{[]byte{0x3D, 0xD8, 'a', 0x00}, binary.LittleEndian, "�a", nil},
+		{[]byte{'a', 0x00, 0x00}, binary.LittleEndian, "", errTruncatedUTF16},
+	} {
+		s, err := DecodeUTF16(tc.give, tc.giveOrder)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, s, tc.want)
+	}
+
+	for _, tc := range []struct {
+		give      []byte
+		giveOrder binary.ByteOrder
+		want      string
+		wantErr   error
+	}{
+		{[]byte{}, binary.LittleEndian, "", nil},
+		{[]byte{'a', 0, 0, 0, 0x2D, 0x4E, 0, 0, 0x00, 0xF6, 0x01, 0x00}, binary.LittleEndian, "a中😀", nil},
+		{[]byte{0, 0, 0, 'a', 0, 0, 0x4E, 0x2D, 0x00, 0x01, 0xF6, 0x00}, binary.BigEndian, "a中😀", nil},
+		{[]byte{0, 0x11, 0, 0, 0, 0, 0xD8, 0x00, 0xFF, 0xFF, 0xFF, 0xFF}, binary.BigEndian, "���", nil},
+		{[]byte{'a', 0, 0}, binary.LittleEndian, "", errTruncatedUTF32},
+	} {
+		s, err := DecodeUTF32(tc.give, tc.giveOrder)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, s, tc.want)
+	}
+}
+
+func TestToUTF8(t *testing.T) {
+	for _, tc := range []struct {
+		give    []byte
+		want    string
+		wantErr error
+	}{
+		{nil, "", nil},
+		{[]byte("a中"), "a中", nil},
+		{[]byte{0xEF, 0xBB, 0xBF, 'a'}, "a", nil},
+		{[]byte{0xFE, 0xFF, 0x00, 'a', 0x4E, 0x2D}, "a中", nil},
+		{[]byte{0xFF, 0xFE, 'a', 0x00, 0x2D, 0x4E}, "a中", nil},
+		{[]byte{0xFF, 0xFE, 0x00, 0x00, 'a', 0, 0, 0}, "a", nil},
+		{[]byte{0x00, 0x00, 0xFE, 0xFF, 0, 0, 0, 'a'}, "a", nil},
+		{[]byte{0xFE, 0xFF, 0x00}, "", errTruncatedUTF16},
+		{[]byte{0x00, 0x00, 0xFE, 0xFF, 0}, "", errTruncatedUTF32},
+		{[]byte{'a', 0xFF}, "", &InvalidUTF8Error{Offset: 1}},
+		{[]byte{0xEF, 0xBB, 0xBF, 'a', 0xFF}, "", &InvalidUTF8Error{Offset: 1}},
+	} {
+		s, err := ToUTF8(tc.give)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, s, tc.want)
+	}
+}
+
+func TestValidateUTF8(t *testing.T) {
+	for _, tc := range []struct {
+		give []byte
+		want int
+	}{
+		{nil, -1},
+		{[]byte("abc"), -1},
+		{[]byte("中文テキスト😀"), -1},
+		{[]byte{0xFF}, 0},
+		{[]byte{'a', 'b', 0x80}, 2},
+		{[]byte("中\xe6\x96"), 3},
+		{[]byte("中\xe6\x96文"), 3},
+		{[]byte("ab\xed\xa0\x80"), 2}, // surrogate
+		{[]byte("ab\xc0\xaf"), 2},     // overlong
+		{[]byte("中文\xf4\x90\x80\x80"), 6},
+	} {
+		xtesting.Equal(t, InvalidUTF8Index(tc.give), tc.want)
+		err := ValidateUTF8(tc.give)
+		if tc.want == -1 {
+			xtesting.Nil(t, err)
+		} else {
+			xtesting.Equal(t, err, &InvalidUTF8Error{Offset: tc.want})
+		}
+	}
+	xtesting.Equal(t, (&InvalidUTF8Error{Offset: 3}).Error(), "xstring: invalid UTF-8 sequence at offset 3")
+}
@@ -0,0 +1,322 @@
+package xstring
+
+import (
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ========================
+// case-insensitive compare
+// ========================
+
+// equalFoldRune checks whether given two runes are equal under Unicode simple case folding, this is the same as the rune
+// comparison in strings.EqualFold.
+func equalFoldRune(r1, r2 rune) bool {
+	if r1 == r2 {
+		return true
+	}
+	if r2 < r1 {
+		r1, r2 = r2, r1
+	}
+	if r2 < utf8.RuneSelf {
+		return 'A' <= r1 && r1 <= 'Z' && r2 == r1+'a'-'A' // ASCII fast path
+	}
+	r := unicode.SimpleFold(r1)
+	for r != r1 && r < r2 {
+		r = unicode.SimpleFold(r)
+	}
+	return r == r2
+}
+
+// foldRune returns the canonical rune used in CompareFold, that is the minimum rune in the simple case folding orbit, and
+// ASCII uppercase letters are mapped to lowercase. Runes equal under equalFoldRune always have the same canonical rune.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}
+	canonical := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < canonical {
+			canonical = f
+		}
+	}
+	if 'A' <= canonical && canonical <= 'Z' {
+		return canonical + 'a' - 'A'
+	}
+	return canonical
+}
+
+// prefixFoldLength checks whether s has given prefix under case folding, and returns the byte length of the matched prefix
+// in s, note that the byte lengths of s's prefix and given prefix may be different, such as "K" (U+212A) and "k".
+func prefixFoldLength(s, prefix string) (int, bool) {
+	i, j := 0, 0
+	for j < len(prefix) {
+		if i >= len(s) {
+			return 0, false
+		}
+		r1, size1 := rune(s[i]), 1
+		if r1 >= utf8.RuneSelf {
+			r1, size1 = utf8.DecodeRuneInString(s[i:])
+		}
+		r2, size2 := rune(prefix[j]), 1
+		if r2 >= utf8.RuneSelf {
+			r2, size2 = utf8.DecodeRuneInString(prefix[j:])
+		}
+		if !equalFoldRune(r1, r2) {
+			return 0, false
+		}
+		i, j = i+size1, j+size2
+	}
+	return i, true
+}
+
+// CompareFold compares two strings lexicographically by their case folded runes without allocation, returns 0 if a equals b,
+// -1 if a < b, and +1 if a > b. Also see strings.EqualFold for equality check.
+//
+// Example:
+// 	CompareFold("Hello", "hELLO")  // => 0
+// 	CompareFold("apple", "Banana") // => -1
+func CompareFold(a, b string) int {
+	for a != "" && b != "" {
+		r1, size1 := utf8.DecodeRuneInString(a)
+		r2, size2 := utf8.DecodeRuneInString(b)
+		if r1 != r2 && !equalFoldRune(r1, r2) {
+			if foldRune(r1) < foldRune(r2) {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[size1:], b[size2:]
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
+		return 1
+	}
+}
+
+// HasPrefixFold checks whether s begins with prefix under Unicode case folding without allocation.
+func HasPrefixFold(s, prefix string) bool {
+	_, ok := prefixFoldLength(s, prefix)
+	return ok
+}
+
+// HasSuffixFold checks whether s ends with suffix under Unicode case folding without allocation.
+func HasSuffixFold(s, suffix string) bool {
+	for suffix != "" {
+		if s == "" {
+			return false
+		}
+		r1, size1 := utf8.DecodeLastRuneInString(s)
+		r2, size2 := utf8.DecodeLastRuneInString(suffix)
+		if !equalFoldRune(r1, r2) {
+			return false
+		}
+		s, suffix = s[:len(s)-size1], suffix[:len(suffix)-size2]
+	}
+	return true
+}
+
+// IndexFold returns the byte index of the first instance of substr in s under Unicode case folding without allocation,
+// returns -1 if substr is not present in s.
+func IndexFold(s, substr string) int {
+	if substr == "" {
+		return 0
+	}
+	for i := 0; i < len(s); {
+		if _, ok := prefixFoldLength(s[i:], substr); ok {
+			return i
+		}
+		if s[i] < utf8.RuneSelf {
+			i++
+		} else {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+	}
+	return -1
+}
+
+// ContainsFold checks whether substr is within s under Unicode case folding without allocation, this is faster than using
+// strings.Contains with strings.ToLower.
+//
+// Example:
+// 	ContainsFold("Hello World", "WORLD") // => true
+// 	ContainsFold("Straße", "STRASSE")    // => false, only simple folding is supported
+func ContainsFold(s, substr string) bool {
+	return IndexFold(s, substr) != -1
+}
+
+// =============
+// ASCII helpers
+// =============
+
+// IsASCII checks whether given string only contains ASCII characters.
+func IsASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// ToUpperASCII converts ASCII lowercase letters in s to uppercase, other characters (including non-ASCII letters) are kept.
+// Note that s is returned directly without allocation if there is no letter to convert.
+func ToUpperASCII(s string) string {
+	return convertASCIICase(s, 'a', 'z', 'A'-'a')
+}
+
+// ToLowerASCII converts ASCII uppercase letters in s to lowercase, other characters (including non-ASCII letters) are kept.
+// Note that s is returned directly without allocation if there is no letter to convert.
+func ToLowerASCII(s string) string {
+	return convertASCIICase(s, 'A', 'Z', 'a'-'A')
+}
+
+// convertASCIICase is the implementation of ToUpperASCII and ToLowerASCII.
+func convertASCIICase(s string, from, to byte, delta int) string {
+	idx := -1
+	for i := 0; i < len(s); i++ {
+		if s[i] >= from && s[i] <= to {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return s
+	}
+	bs := make([]byte, len(s))
+	copy(bs, s[:idx])
+	for i := idx; i < len(s); i++ {
+		c := s[i]
+		if c >= from && c <= to {
+			c = byte(int(c) + delta)
+		}
+		bs[i] = c
+	}
+	return FastBtos(bs)
+}
+
+// =======
+// Builder
+// =======
+
+// Builder represents a reusable string builder, which is used with AcquireBuilder and ReleaseBuilder to avoid allocation.
+// Different from strings.Builder, Builder keeps its buffer when Reset, and the String method returns a copy of buffer.
+type Builder struct {
+	buf []byte
+}
+
+const (
+	// builderDefaultCap is the default capacity of Builder created by AcquireBuilder.
+	builderDefaultCap = 64
+
+	// builderMaxPooledCap is the max capacity of Builder which can be put back to the pool, the larger Builder will be dropped.
+	builderMaxPooledCap = 64 * 1024
+)
+
+var _builderPool = sync.Pool{New: func() interface{} {
+	return &Builder{buf: make([]byte, 0, builderDefaultCap)}
+}}
+
+// AcquireBuilder gets an empty Builder from the pool, please call ReleaseBuilder when the Builder is no longer used.
+func AcquireBuilder() *Builder {
+	return _builderPool.Get().(*Builder)
+}
+
+// ReleaseBuilder resets given Builder and puts it back to the pool, note that the Builder must not be used after releasing.
+func ReleaseBuilder(b *Builder) {
+	if b == nil || cap(b.buf) > builderMaxPooledCap {
+		return
+	}
+	b.Reset()
+	_builderPool.Put(b)
+}
+
+// BuildString builds a string using a pooled Builder, the Builder is acquired before calling fn and is released after
+// getting the result string.
+//
+// Example:
+// 	BuildString(func(b *Builder) {
+// 		b.WriteString("hello")
+// 		b.WriteByte(' ')
+// 		b.WriteRune('世')
+// 	}) // => hello 世
+func BuildString(fn func(b *Builder)) string {
+	b := AcquireBuilder()
+	fn(b)
+	s := b.String()
+	ReleaseBuilder(b)
+	return s
+}
+
+// Len returns the number of accumulated bytes.
+func (b *Builder) Len() int {
+	return len(b.buf)
+}
+
+// Cap returns the capacity of the underlying buffer.
+func (b *Builder) Cap() int {
+	return cap(b.buf)
+}
+
+// Grow grows the capacity of buffer to guarantee space for another n bytes.
+func (b *Builder) Grow(n int) {
+	if n > 0 && cap(b.buf)-len(b.buf) < n {
+		buf := make([]byte, len(b.buf), 2*cap(b.buf)+n)
+		copy(buf, b.buf)
+		b.buf = buf
+	}
+}
+
+// Reset resets the Builder to be empty, but keeps the underlying buffer for reusing.
+func (b *Builder) Reset() {
+	b.buf = b.buf[:0]
+}
+
+// Write appends the contents of p to the buffer, it implements io.Writer and always returns a nil error.
+func (b *Builder) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteByte appends the byte c to the buffer, it implements io.ByteWriter and always returns a nil error.
+func (b *Builder) WriteByte(c byte) error {
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of r to the buffer, it always returns a nil error.
+func (b *Builder) WriteRune(r rune) (int, error) {
+	if r >= 0 && r < utf8.RuneSelf {
+		b.buf = append(b.buf, byte(r))
+		return 1, nil
+	}
+	var tmp [utf8.UTFMax]byte
+	n := utf8.EncodeRune(tmp[:], r)
+	b.buf = append(b.buf, tmp[:n]...)
+	return n, nil
+}
+
+// WriteString appends the contents of s to the buffer, it implements io.StringWriter and always returns a nil error.
+func (b *Builder) WriteString(s string) (int, error) {
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// Bytes returns the accumulated bytes, note that it is only valid until the next modification or releasing.
+func (b *Builder) Bytes() []byte {
+	return b.buf
+}
+
+// String returns a copy of the accumulated string, so it is still valid after the Builder is reset or released.
+func (b *Builder) String() string {
+	return string(b.buf)
+}
@@ -0,0 +1,147 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCompareFold(t *testing.T) {
+	for _, tc := range []struct {
+		giveA string
+		giveB string
+		want  int
+	}{
+		{"", "", 0},
+		{"a", "", 1},
+		{"", "a", -1},
+		{"Hello", "hELLO", 0},
+		{"apple", "Banana", -1},
+		{"Banana", "apple", 1},
+		{"abc", "ABCD", -1},
+		{"abcd", "ABC", 1},
+		{"a_b", "A[B", 1}, // '_' > '[' but 'b' folded
+		{"Straße", "STRASSE", 1},
+		{"ΑΒΓ", "αβγ", 0},
+		{"K", "k", 0}, // Kelvin sign
+		{"ǅ", "ǆ", 0},
+		{"中文", "中文", 0},
+		{"中", "文", -1},
+	} {
+		xtesting.Equal(t, CompareFold(tc.giveA, tc.giveB), tc.want)
+		xtesting.Equal(t, CompareFold(tc.giveB, tc.giveA), -tc.want)
+		xtesting.Equal(t, CompareFold(tc.giveA, tc.giveB) == 0, strings.EqualFold(tc.giveA, tc.giveB))
+	}
+}
+
+func TestXXXFold(t *testing.T) {
+	for _, tc := range []struct {
+		giveS      string
+		giveSub    string
+		wantPrefix bool
+		wantSuffix bool
+		wantIndex  int
+	}{
+		{"", "", true, true, 0},
+		{"abc", "", true, true, 0},
+		{"", "a", false, false, -1},
+		{"Hello World", "hello", true, false, 0},
+		{"Hello World", "WORLD", false, true, 6},
+		{"Hello World", "O W", false, false, 4},
+		{"Hello World", "xyz", false, false, -1},
+		{"Hello", "hello world", false, false, -1},
+		{"中文Text", "TEXT", false, true, 6},
+		{"中文Text", "中文t", true, false, 0},
+		{"Kelvin", "KEL", true, false, 0},
+		{"xK", "k", false, true, 1},
+		{"ΣΊΣΥΦΟΣ", "σίσυφος", true, true, 0},
+		{"Straße", "STRASSE", false, false, -1},
+	} {
+		xtesting.Equal(t, HasPrefixFold(tc.giveS, tc.giveSub), tc.wantPrefix)
+		xtesting.Equal(t, HasSuffixFold(tc.giveS, tc.giveSub), tc.wantSuffix)
+		xtesting.Equal(t, IndexFold(tc.giveS, tc.giveSub), tc.wantIndex)
+		xtesting.Equal(t, ContainsFold(tc.giveS, tc.giveSub), tc.wantIndex != -1)
+	}
+}
+
+func TestXXXASCII(t *testing.T) {
+	for _, tc := range []struct {
+		give      string
+		wantASCII bool
+		wantUpper string
+		wantLower string
+	}{
+		{"", true, "", ""},
+		{"123 _-", true, "123 _-", "123 _-"},
+		{"Hello World", true, "HELLO WORLD", "hello world"},
+		{"ABC", true, "ABC", "abc"},
+		{"abc", true, "ABC", "abc"},
+		{"Ünïcode Ab", false, "ÜNïCODE AB", "Ünïcode ab"},
+		{"中文テキスト", false, "中文テキスト", "中文テキスト"},
+	} {
+		xtesting.Equal(t, IsASCII(tc.give), tc.wantASCII)
+		xtesting.Equal(t, ToUpperASCII(tc.give), tc.wantUpper)
+		xtesting.Equal(t, ToLowerASCII(tc.give), tc.wantLower)
+	}
+
+	s := "already lower"
+	xtesting.Equal(t, testing.AllocsPerRun(10, func() { _ = ToLowerASCII(s) }), 0.0)
+	xtesting.Equal(t, testing.AllocsPerRun(10, func() { _ = ToUpperASCII("UPPER") }), 0.0)
+	xtesting.Equal(t, testing.AllocsPerRun(10, func() { _ = ContainsFold("Hello World", "WORLD") }), 0.0)
+	xtesting.Equal(t, testing.AllocsPerRun(10, func() { _ = CompareFold("Hello World", "HELLO WORLD") }), 0.0)
+}
+
+func TestBuilder(t *testing.T) {
+	b := AcquireBuilder()
+	xtesting.Equal(t, b.Len(), 0)
+	xtesting.True(t, b.Cap() >= builderDefaultCap)
+	n, err := b.WriteString("hello")
+	xtesting.Equal(t, n, 5)
+	xtesting.Nil(t, err)
+	xtesting.Nil(t, b.WriteByte(' '))
+	n, _ = b.WriteRune('世')
+	xtesting.Equal(t, n, 3)
+	n, _ = b.WriteRune('!')
+	xtesting.Equal(t, n, 1)
+	n, _ = b.Write([]byte("ab"))
+	xtesting.Equal(t, n, 2)
+	xtesting.Equal(t, b.Len(), 12)
+	xtesting.Equal(t, b.Bytes(), []byte("hello 世!ab"))
+	s := b.String()
+	xtesting.Equal(t, s, "hello 世!ab")
+
+	capacity := b.Cap()
+	b.Reset()
+	xtesting.Equal(t, b.Len(), 0)
+	xtesting.Equal(t, b.Cap(), capacity)
+	b.Grow(0)
+	xtesting.Equal(t, b.Cap(), capacity)
+	b.Grow(capacity + 1)
+	xtesting.True(t, b.Cap() >= capacity+1)
+	_, _ = b.WriteString("changed")
+	xtesting.Equal(t, s, "hello 世!ab")
+	ReleaseBuilder(b)
+	ReleaseBuilder(nil)
+
+	large := &Builder{}
+	large.Grow(builderMaxPooledCap + 1)
+	ReleaseBuilder(large) // dropped
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s := BuildString(func(b *Builder) {
+					xtesting.Equal(t, b.Len(), 0)
+					_, _ = b.WriteString("x")
+					_, _ = b.WriteRune(rune('a' + i))
+				})
+				xtesting.Equal(t, s, "x"+string(rune('a'+i)))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,307 @@
+package xstring
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MissingKeyPolicy represents the policy used when a placeholder cannot be found in args, is used in FormatWithPolicy and
+// Template.ExecuteWithPolicy.
+type MissingKeyPolicy uint8
+
+const (
+	// MissingKeyError means returning a *TemplateKeyError error.
+	MissingKeyError MissingKeyPolicy = iota
+
+	// MissingKeyKeep means keeping the placeholder as it is, such as `{name:%03d}`.
+	MissingKeyKeep
+
+	// MissingKeyEmpty means replacing the placeholder with empty string.
+	MissingKeyEmpty
+)
+
+// TemplateKeyError represents an error that a placeholder cannot be found in args, is returned by Format and Template.Execute
+// when using MissingKeyError policy.
+type TemplateKeyError struct {
+	// Key represents the name of the missing placeholder.
+	Key string
+}
+
+// Error returns the formatted TemplateKeyError.
+func (t *TemplateKeyError) Error() string {
+	return fmt.Sprintf("xstring: missing key `%s` in template args", t.Key)
+}
+
+var (
+	errTemplateUnclosed  = errors.New("xstring: unclosed placeholder in template")
+	errTemplateUnmatched = errors.New("xstring: unmatched '}' in template")
+	errTemplateEmptyName = errors.New("xstring: empty placeholder name in template")
+)
+
+// templateSegment represents a literal text or a placeholder in Template.
+type templateSegment struct {
+	literal     string   // literal text, or the original placeholder text
+	placeholder bool     // is a placeholder
+	path        []string // placeholder name split by dot
+	verb        string   // fmt verb, empty for fmt.Sprint
+}
+
+// Template represents a precompiled named-placeholder template, which is safe for concurrent use, and is created by
+// CompileTemplate or MustCompileTemplate.
+type Template struct {
+	format   string
+	segments []templateSegment
+}
+
+// CompileTemplate compiles given format to Template. Here `{name}` is a placeholder which will be formatted by fmt.Sprint,
+// `{name:%03d}` is a placeholder with fmt verb (the leading % can be omitted), `{a.b}` means looking up field b in value a,
+// and `{{` and `}}` are the escaped braces.
+//
+// Example:
+// 	t, _ := CompileTemplate("Hello {name}, you have {count:%03d} messages, {{escaped}}")
+// 	t.Execute(map[string]interface{}{"name": "Alice", "count": 7}) // => Hello Alice, you have 007 messages, {escaped}
+func CompileTemplate(format string) (*Template, error) {
+	segments := make([]templateSegment, 0)
+	literal := strings.Builder{}
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, templateSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case c == '{' && i+1 < len(format) && format[i+1] == '{':
+			literal.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(format) && format[i+1] == '}':
+			literal.WriteByte('}')
+			i++
+		case c == '}':
+			return nil, errTemplateUnmatched
+		case c == '{':
+			end := strings.IndexByte(format[i:], '}')
+			if end == -1 {
+				return nil, errTemplateUnclosed
+			}
+			original := format[i : i+end+1]
+			content := original[1 : len(original)-1]
+			if strings.IndexByte(content, '{') != -1 {
+				return nil, errTemplateUnclosed
+			}
+			name, verb := content, ""
+			if idx := strings.IndexByte(content, ':'); idx != -1 {
+				name, verb = content[:idx], content[idx+1:]
+				if verb != "" && verb[0] != '%' {
+					verb = "%" + verb
+				}
+			}
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return nil, errTemplateEmptyName
+			}
+			flushLiteral()
+			segments = append(segments, templateSegment{literal: original, placeholder: true, path: strings.Split(name, "."), verb: verb})
+			i += end
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flushLiteral()
+	return &Template{format: format, segments: segments}, nil
+}
+
+// MustCompileTemplate compiles given format to Template, and panics if the format is invalid, see CompileTemplate.
+func MustCompileTemplate(format string) *Template {
+	t, err := CompileTemplate(format)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// String returns the original format of Template.
+func (t *Template) String() string {
+	return t.format
+}
+
+// Names returns the distinct placeholder names of Template, in the order of their first occurrence.
+func (t *Template) Names() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, seg := range t.segments {
+		if seg.placeholder {
+			name := strings.Join(seg.path, ".")
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Execute formats Template with given args using MissingKeyError policy, see ExecuteWithPolicy for details.
+func (t *Template) Execute(args interface{}) (string, error) {
+	return t.ExecuteWithPolicy(args, MissingKeyError)
+}
+
+// ExecuteWithPolicy formats Template with given args and MissingKeyPolicy. Here args can be a map with string key, a struct
+// (or pointer to struct) whose fields are looked up by `format` tag or field name, or an OrderedMapLike such as
+// xorderedmap.OrderedMap, and these types can be nested by using dot in placeholder name.
+func (t *Template) ExecuteWithPolicy(args interface{}, policy MissingKeyPolicy) (string, error) {
+	sb := strings.Builder{}
+	for _, seg := range t.segments {
+		if !seg.placeholder {
+			sb.WriteString(seg.literal)
+			continue
+		}
+		value, ok := lookupTemplateValue(args, seg.path)
+		if !ok {
+			switch policy {
+			case MissingKeyKeep:
+				sb.WriteString(seg.literal)
+			case MissingKeyEmpty:
+			default:
+				return "", &TemplateKeyError{Key: strings.Join(seg.path, ".")}
+			}
+			continue
+		}
+		if seg.verb == "" {
+			sb.WriteString(fmt.Sprint(value))
+		} else {
+			sb.WriteString(fmt.Sprintf(seg.verb, value))
+		}
+	}
+	return sb.String(), nil
+}
+
+// Format formats given format with named placeholders using args and MissingKeyError policy, see CompileTemplate and
+// Template.ExecuteWithPolicy for details. Please use Template for hot paths to avoid parsing format every time.
+//
+// Example:
+// 	Format("Hello {name}, you have {count:%03d} messages", map[string]interface{}{"name": "Alice", "count": 7})
+// 	// => Hello Alice, you have 007 messages
+func Format(format string, args interface{}) (string, error) {
+	return FormatWithPolicy(format, args, MissingKeyError)
+}
+
+// FormatWithPolicy formats given format with named placeholders using args and MissingKeyPolicy, see CompileTemplate and
+// Template.ExecuteWithPolicy for details.
+func FormatWithPolicy(format string, args interface{}, policy MissingKeyPolicy) (string, error) {
+	t, err := CompileTemplate(format)
+	if err != nil {
+		return "", err
+	}
+	return t.ExecuteWithPolicy(args, policy)
+}
+
+// lookupTemplateValue looks up the value of given path from args.
+func lookupTemplateValue(args interface{}, path []string) (interface{}, bool) {
+	current := args
+	for _, key := range path {
+		value, ok := lookupTemplateKey(current, key)
+		if !ok {
+			return nil, false
+		}
+		current = value
+	}
+	return current, true
+}
+
+// lookupTemplateKey looks up the value of given key from map, struct or OrderedMapLike.
+func lookupTemplateKey(args interface{}, key string) (interface{}, bool) {
+	switch m := args.(type) {
+	case nil:
+		return nil, false
+	case map[string]interface{}:
+		v, ok := m[key]
+		return v, ok
+	case map[string]string:
+		v, ok := m[key]
+		return v, ok
+	case OrderedMapLike:
+		return m.Get(key)
+	}
+
+	val := reflect.ValueOf(args)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil, false
+		}
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		v := val.MapIndex(reflect.ValueOf(key).Convert(val.Type().Key()))
+		if !v.IsValid() {
+			return nil, false
+		}
+		return v.Interface(), true
+	case reflect.Struct:
+		idx, ok := templateFieldIndexes(val.Type())[key]
+		if !ok {
+			return nil, false
+		}
+		return val.FieldByIndex(idx).Interface(), true
+	}
+	return nil, false
+}
+
+// _templateFieldCache caches the field indexes of struct types, that is map[reflect.Type]map[string][]int.
+var _templateFieldCache sync.Map
+
+// templateFieldIndexes returns the exported field indexes of given struct type, keyed by `format` tag or field name. Note
+// that the fields of anonymous struct are promoted, and fields with `format:"-"` are ignored. Same as Go's selector rule,
+// the shallowest field takes precedence, and the fields with the same name at the same shallowest depth are ignored.
+func templateFieldIndexes(typ reflect.Type) map[string][]int {
+	if cached, ok := _templateFieldCache.Load(typ); ok {
+		return cached.(map[string][]int)
+	}
+	indexes := make(map[string][]int)
+	depths := make(map[string]int)
+	ambiguous := make(map[string]bool)
+	var collect func(typ reflect.Type, prefix []int)
+	collect = func(typ reflect.Type, prefix []int) {
+		for i := 0; i < typ.NumField(); i++ {
+			sf := typ.Field(i)
+			index := append(append([]int{}, prefix...), i)
+			tag := sf.Tag.Get("format")
+			if tag == "-" {
+				continue
+			}
+			if sf.Anonymous && tag == "" && sf.Type.Kind() == reflect.Struct {
+				collect(sf.Type, index)
+				continue
+			}
+			if sf.PkgPath != "" { // unexported
+				continue
+			}
+			name := tag
+			if name == "" {
+				name = sf.Name
+			}
+			if depth, exist := depths[name]; !exist || len(index) < depth { // shallower fields take precedence
+				indexes[name], depths[name], ambiguous[name] = index, len(index), false
+			} else if len(index) == depth {
+				ambiguous[name] = true
+			}
+		}
+	}
+	collect(typ, nil)
+	for name, ok := range ambiguous {
+		if ok {
+			delete(indexes, name)
+		}
+	}
+	_templateFieldCache.Store(typ, indexes)
+	return indexes
+}
@@ -0,0 +1,187 @@
+package xstring
+
+import (
+	"errors"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"sync"
+	"testing"
+)
+
+type testFormatBase struct {
+	ID   int
+	Name string `format:"base_name"`
+}
+
+type testFormatUser struct {
+	testFormatBase
+	Name    string `format:"name"`
+	Age     int
+	Secret  string `format:"-"`
+	Profile *testFormatProfile
+	private string
+}
+
+type testFormatProfile struct {
+	City string `format:"city"`
+}
+
+type testFormatDeep struct {
+	Level string
+	Deep  string `format:"deep"`
+}
+
+type testFormatMiddle struct {
+	testFormatDeep
+	Level string
+	Tie   string
+}
+
+type testFormatOther struct {
+	Tie string
+}
+
+type testFormatNested struct {
+	testFormatMiddle
+	testFormatOther
+}
+
+func TestFormat(t *testing.T) {
+	args := map[string]interface{}{"name": "Alice", "count": 7, "ratio": 0.25, "nested": map[string]int{"x": 1}}
+	for _, tc := range []struct {
+		give    string
+		want    string
+		wantErr error
+	}{
+		{"", "", nil},
+		{"plain text", "plain text", nil},
+		{"Hello {name}, you have {count:%03d} messages", "Hello Alice, you have 007 messages", nil},
+		{"{count:03d}|{count:x}|{ratio:%.1f}|{ratio:%6.2f}|{name:%q}", "007|7|0.2|  0.25|\"Alice\"", nil},
+		{"{ name }{name:}", "AliceAlice", nil},
+		{"{nested.x}", "1", nil},
+		{"{nested}", "map[x:1]", nil},
+		{"{{name}} {{{name}}} }}{{", "{name} {Alice} }{", nil},
+		{"中文{name}テキスト", "中文Aliceテキスト", nil},
+		{"{", "", errTemplateUnclosed},
+		{"{name", "", errTemplateUnclosed},
+		{"{na{me}", "", errTemplateUnclosed},
+		{"}", "", errTemplateUnmatched},
+		{"a}b", "", errTemplateUnmatched},
+		{"{}", "", errTemplateEmptyName},
+		{"{:%d}", "", errTemplateEmptyName},
+		{"{missing}", "", &TemplateKeyError{Key: "missing"}},
+		{"{nested.y}", "", &TemplateKeyError{Key: "nested.y"}},
+		{"{name.x}", "", &TemplateKeyError{Key: "name.x"}},
+	} {
+		s, err := Format(tc.give, args)
+		xtesting.Equal(t, s, tc.want)
+		xtesting.Equal(t, err, tc.wantErr)
+	}
+	xtesting.Equal(t, (&TemplateKeyError{Key: "a.b"}).Error(), "xstring: missing key `a.b` in template args")
+}
+
+func TestFormatWithPolicy(t *testing.T) {
+	args := map[string]string{"a": "1"}
+	for _, tc := range []struct {
+		give       string
+		givePolicy MissingKeyPolicy
+		want       string
+		wantErr    bool
+	}{
+		{"{a}-{b}-{c:%03d}", MissingKeyError, "", true},
+		{"{a}-{b}-{c:%03d}", MissingKeyKeep, "1-{b}-{c:%03d}", false},
+		{"{a}-{b}-{c:%03d}", MissingKeyEmpty, "1--", false},
+		{"{a}-{ b }", MissingKeyKeep, "1-{ b }", false},
+		{"{a}", 255, "1", false},
+		{"{b}", 255, "", true},
+	} {
+		s, err := FormatWithPolicy(tc.give, args, tc.givePolicy)
+		xtesting.Equal(t, s, tc.want)
+		xtesting.Equal(t, err != nil, tc.wantErr)
+	}
+
+	_, err := FormatWithPolicy("{", args, MissingKeyKeep)
+	xtesting.Equal(t, err, errTemplateUnclosed)
+	s, err := FormatWithPolicy("{a}", nil, MissingKeyKeep)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, s, "{a}")
+}
+
+func TestFormatArgs(t *testing.T) {
+	user := &testFormatUser{
+		testFormatBase: testFormatBase{ID: 1, Name: "base"},
+		Name:           "Bob",
+		Age:            20,
+		Secret:         "secret",
+		Profile:        &testFormatProfile{City: "Tokyo"},
+		private:        "private",
+	}
+	nested := &testFormatNested{
+		testFormatMiddle: testFormatMiddle{testFormatDeep: testFormatDeep{Level: "depth2", Deep: "deep"}, Level: "depth1", Tie: "a"},
+		testFormatOther:  testFormatOther{Tie: "b"},
+	}
+	for _, tc := range []struct {
+		give    string
+		giveArg interface{}
+		want    string
+		wantErr bool
+	}{
+		{"{name}/{Age}/{ID}/{base_name}/{Profile.city}", user, "Bob/20/1/base/Tokyo", false},
+		{"{name}/{Age}/{ID}/{base_name}/{Profile.city}", *user, "Bob/20/1/base/Tokyo", false},
+		{"{Name}", user, "", true},
+		{"{Secret}", user, "", true},
+		{"{private}", user, "", true},
+		{"{Profile.City}", user, "", true},
+		{"{Profile.city}", &testFormatUser{}, "", true},
+		{"{Profile.city}", (*testFormatUser)(nil), "", true},
+		{"{u.name}", map[string]interface{}{"u": user}, "Bob", false},
+		{"{u.name}", map[string]*testFormatUser{"u": user}, "Bob", false},
+		{"{a}", map[int]string{1: "x"}, "", true},
+		{"{a}", 1, "", true},
+		{"{z}-{y.a}", &testOrderedMap{keys: []string{"z", "y"}, values: map[string]interface{}{
+			"z": 1, "y": &testOrderedMap{keys: []string{"a"}, values: map[string]interface{}{"a": "2"}}}}, "1-2", false},
+		{"{x}", map[string]interface{}{"x": nil}, "<nil>", false},
+		{"{x.y}", map[string]interface{}{"x": nil}, "", true},
+		{"{Level}/{deep}", nested, "depth1/deep", false},
+		{"{Tie}", nested, "", true},
+	} {
+		s, err := Format(tc.give, tc.giveArg)
+		xtesting.Equal(t, s, tc.want)
+		xtesting.Equal(t, err != nil, tc.wantErr)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := CompileTemplate("[{level:%-5s}] {msg} ({level})")
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, tmpl.String(), "[{level:%-5s}] {msg} ({level})")
+	xtesting.Equal(t, tmpl.Names(), []string{"level", "msg"})
+	s, err := tmpl.Execute(map[string]string{"level": "INFO", "msg": "started"})
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, s, "[INFO ] started (INFO)")
+	s, err = tmpl.ExecuteWithPolicy(map[string]string{"level": "WARN"}, MissingKeyKeep)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, s, "[WARN ] {msg} (WARN)")
+	_, err = tmpl.Execute(map[string]string{"level": "WARN"})
+	keyErr := &TemplateKeyError{}
+	xtesting.True(t, errors.As(err, &keyErr))
+	xtesting.Equal(t, keyErr.Key, "msg")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s, err := tmpl.Execute(&testFormatUser{})
+				xtesting.Equal(t, s, "")
+				xtesting.NotNil(t, err)
+				s, _ = MustCompileTemplate("{name}-{Age}").Execute(testFormatUser{Name: "a", Age: j})
+				xtesting.NotEqual(t, s, "")
+			}
+		}()
+	}
+	wg.Wait()
+
+	xtesting.Equal(t, MustCompileTemplate("").Names(), []string{})
+	xtesting.PanicWithValue(t, errTemplateUnmatched, func() { MustCompileTemplate("}") })
+}
@@ -0,0 +1,407 @@
+package xstring
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ====
+// uuid
+// ====
+
+// UUID represents a RFC 9562 universally unique identifier, which is 128 bits long. Note that only version 4 and version 7
+// can be generated by this package, but any version can be parsed.
+type UUID [16]byte
+
+// NilUUID represents the nil UUID, that is 00000000-0000-0000-0000-000000000000.
+var NilUUID = UUID{}
+
+var (
+	errInvalidUUID = errors.New("xstring: invalid uuid string")
+)
+
+// NewUUIDv4 generates a version 4 UUID, which is filled by 122 random bits from crypto/rand.
+func NewUUIDv4() (UUID, error) {
+	u := UUID{}
+	if _, err := io.ReadFull(rand.Reader, u[:]); err != nil {
+		return NilUUID, err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return u, nil
+}
+
+// uuidV7State represents the global state used to keep version 7 UUIDs monotonic within the same millisecond.
+type uuidV7State struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	lastMs int64
+	seq    uint16 // 12 bits, used as rand_a field
+}
+
+var _uuidV7 = &uuidV7State{now: time.Now}
+
+// NewUUIDv7 generates a version 7 UUID, which is combined by 48 bits unix timestamp in milliseconds, 12 bits counter in the
+// rand_a field (see RFC 9562, section 6.2, method 1), and 62 random bits in the rand_b field from crypto/rand. The counter
+// starts from a random 11 bits value in each new millisecond and is incremented within the same millisecond, so the UUIDs
+// generated in this process are strictly increasing, and only the rand_b field should be regarded as random.
+func NewUUIDv7() (UUID, error) {
+	u := UUID{}
+	if _, err := io.ReadFull(rand.Reader, u[6:]); err != nil {
+		return NilUUID, err
+	}
+
+	s := _uuidV7
+	s.mu.Lock()
+	ms := s.now().UnixNano() / int64(time.Millisecond)
+	if ms > s.lastMs {
+		s.seq = binary.BigEndian.Uint16(u[6:8]) & 0x07ff // random start, with the highest bit cleared to leave room for counting
+	} else {
+		ms = s.lastMs // clock moved backwards or in the same millisecond
+		s.seq++
+		if s.seq > 0x0fff {
+			ms++ // counter overflows, borrow the next millisecond
+			s.seq = 0
+		}
+	}
+	s.lastMs = ms
+	seq := s.seq
+	s.mu.Unlock()
+
+	u[0], u[1], u[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	u[3], u[4], u[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+	u[6] = 0x70 | byte(seq>>8) // version 7
+	u[7] = byte(seq)
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return u, nil
+}
+
+// ParseUUID parses a UUID from given string, the canonical form `xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`, the URN form
+// `urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`, the braced form `{xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}` and the 32
+// hex digits form are all supported, and hex digits are case-insensitive.
+func ParseUUID(s string) (UUID, error) {
+	switch {
+	case len(s) == 36+9 && strings.EqualFold(s[:9], "urn:uuid:"):
+		s = s[9:]
+	case len(s) == 36+2 && s[0] == '{' && s[len(s)-1] == '}':
+		s = s[1 : len(s)-1]
+	}
+
+	var digits string
+	switch len(s) {
+	case 32:
+		digits = s
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return NilUUID, errInvalidUUID
+		}
+		digits = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return NilUUID, errInvalidUUID
+	}
+
+	u := UUID{}
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return NilUUID, errInvalidUUID
+	}
+	return u, nil
+}
+
+// IsValidUUID checks whether given string is a valid UUID, see ParseUUID for the supported forms.
+func IsValidUUID(s string) bool {
+	_, err := ParseUUID(s)
+	return err == nil
+}
+
+// String returns the canonical form of UUID, that is `xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx` in lowercase.
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// Version returns the version of UUID, that is the highest 4 bits of the 7th byte.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns the unix timestamp in milliseconds of a version 7 UUID, and returns zero time.Time for other versions.
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.Unix(ms/1e3, (ms%1e3)*int64(time.Millisecond))
+}
+
+// MarshalText implements encoding.TextMarshaler, returns the canonical form of UUID.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseUUID for the supported forms.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// ====
+// ulid
+// ====
+
+// ULID represents a universally unique lexicographically sortable identifier, which is combined by 48 bits unix timestamp
+// in milliseconds and 80 random bits, and is encoded as 26 characters in Crockford's base32. For more details, please visit
+// https://github.com/ulid/spec.
+type ULID [16]byte
+
+const (
+	// crockfordAlphabet is the Crockford's base32 alphabet, which excludes I, L, O and U.
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+var (
+	errInvalidULID  = errors.New("xstring: invalid ulid string")
+	errULIDOverflow = errors.New("xstring: ulid entropy overflows in the same millisecond")
+)
+
+// ULIDGenerator represents a ULID generator, which is safe for concurrent use. In monotonic mode, the random part will be
+// incremented by 1 when generating in the same millisecond, so the ULIDs are strictly increasing.
+type ULIDGenerator struct {
+	mu        sync.Mutex
+	monotonic bool
+	now       func() time.Time
+	lastMs    uint64
+	last      ULID
+}
+
+// NewULIDGenerator creates a ULIDGenerator with given monotonic mode.
+func NewULIDGenerator(monotonic bool) *ULIDGenerator {
+	return &ULIDGenerator{monotonic: monotonic, now: time.Now}
+}
+
+var _defaultULIDGenerator = NewULIDGenerator(false)
+
+// NewULID generates a ULID using the default non-monotonic ULIDGenerator.
+func NewULID() (ULID, error) {
+	return _defaultULIDGenerator.New()
+}
+
+// New generates a ULID, the random part is read from crypto/rand. Note that in monotonic mode, an error will be returned
+// if the random part overflows in the same millisecond, which hardly happens.
+func (g *ULIDGenerator) New() (ULID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixNano() / int64(time.Millisecond))
+	u := ULID{}
+	if g.monotonic && ms <= g.lastMs && g.lastMs != 0 {
+		// increment the random part of the last ULID, and keep the last timestamp even if clock moved backwards
+		u = g.last
+		i := len(u) - 1
+		for ; i >= 6; i-- {
+			u[i]++
+			if u[i] != 0 {
+				break
+			}
+		}
+		if i < 6 {
+			return ULID{}, errULIDOverflow
+		}
+	} else {
+		if _, err := io.ReadFull(rand.Reader, u[6:]); err != nil {
+			return ULID{}, err
+		}
+		u[0], u[1], u[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+		u[3], u[4], u[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+		g.lastMs = ms
+	}
+	g.last = u
+	return u, nil
+}
+
+// ParseULID parses a ULID from given 26 characters string, letters are case-insensitive, and I, L and O are regarded as
+// 1, 1 and 0 respectively.
+func ParseULID(s string) (ULID, error) {
+	if len(s) != 26 {
+		return ULID{}, errInvalidULID
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := crockfordValue(s[i])
+		if v < 0 || (i == 0 && v > 7) { // the first character is at most 7, to fit in 128 bits
+			return ULID{}, errInvalidULID
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	u := ULID{}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
+
+// crockfordValue returns the value of given Crockford's base32 character, and returns -1 if it is invalid.
+func crockfordValue(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch c {
+	case 'I', 'L':
+		return 1
+	case 'O':
+		return 0
+	}
+	return strings.IndexByte(crockfordAlphabet, c)
+}
+
+// String returns the 26 characters Crockford's base32 form of ULID.
+func (u ULID) String() string {
+	hi, lo := binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+	buf := make([]byte, 26)
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf)
+}
+
+// Time returns the timestamp part of ULID.
+func (u ULID) Time() time.Time {
+	ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.Unix(ms/1e3, (ms%1e3)*int64(time.Millisecond))
+}
+
+// MarshalText implements encoding.TextMarshaler, returns the Crockford's base32 form of ULID.
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseULID for details.
+func (u *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// =========
+// snowflake
+// =========
+
+const (
+	// snowflakeTimeBits is the number of bits of timestamp part in snowflake id.
+	snowflakeTimeBits = 41
+
+	// snowflakeNodeSeqBits is the number of bits of node part and sequence part in snowflake id.
+	snowflakeNodeSeqBits = 22
+)
+
+var (
+	errSnowflakeNodeBits      = errors.New("xstring: snowflake node bits must be less than 22")
+	errSnowflakeNodeRange     = errors.New("xstring: snowflake node is out of range")
+	errSnowflakeEpoch         = errors.New("xstring: snowflake epoch is in the future")
+	errSnowflakeClockBackward = errors.New("xstring: clock moved backwards, refuse to generate snowflake id")
+	errSnowflakeTimeOverflow  = errors.New("xstring: snowflake timestamp overflows")
+)
+
+// Snowflake represents a Twitter snowflake id generator, which is safe for concurrent use. A snowflake id is a positive
+// int64 combined by 41 bits timestamp in milliseconds since epoch, some bits node id, and the remaining bits sequence,
+// and node bits and sequence bits are 22 bits in total.
+//
+// Format like:
+// 	0 | 41 bits timestamp | nodeBits bits node | 22-nodeBits bits sequence
+type Snowflake struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	epochMs  int64
+	nodeBits uint8
+	seqBits  uint8
+	node     int64
+	lastMs   int64
+	seq      int64
+}
+
+// NewSnowflake creates a Snowflake with given epoch, node bits and node id. Note that nodeBits must be less than 22, and
+// node must be in [0, 2^nodeBits), the default snowflake uses 10 node bits and 12 sequence bits.
+func NewSnowflake(epoch time.Time, nodeBits uint8, node int64) (*Snowflake, error) {
+	if nodeBits >= snowflakeNodeSeqBits {
+		return nil, errSnowflakeNodeBits
+	}
+	if node < 0 || node >= 1<<nodeBits {
+		return nil, errSnowflakeNodeRange
+	}
+	if epoch.After(time.Now()) {
+		return nil, errSnowflakeEpoch
+	}
+	return &Snowflake{
+		now:      time.Now,
+		epochMs:  epoch.UnixNano() / int64(time.Millisecond),
+		nodeBits: nodeBits,
+		seqBits:  snowflakeNodeSeqBits - nodeBits,
+		node:     node,
+		lastMs:   -1,
+	}, nil
+}
+
+// Next generates the next snowflake id. Note that it will wait for the next millisecond if the sequence is exhausted in
+// the current millisecond, and returns an error if the clock moved backwards or the timestamp overflows.
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := s.elapsedMs()
+	if ms < s.lastMs {
+		return 0, errSnowflakeClockBackward
+	}
+	if ms == s.lastMs {
+		s.seq = (s.seq + 1) & (1<<s.seqBits - 1)
+		if s.seq == 0 {
+			for ms <= s.lastMs { // sequence is exhausted, wait for the next millisecond
+				time.Sleep(time.Microsecond * 100)
+				ms = s.elapsedMs()
+			}
+		}
+	} else {
+		s.seq = 0
+	}
+	if ms >= 1<<snowflakeTimeBits {
+		return 0, errSnowflakeTimeOverflow
+	}
+	s.lastMs = ms
+	return ms<<snowflakeNodeSeqBits | s.node<<s.seqBits | s.seq, nil
+}
+
+// elapsedMs returns the milliseconds elapsed since epoch.
+func (s *Snowflake) elapsedMs() int64 {
+	return s.now().UnixNano()/int64(time.Millisecond) - s.epochMs
+}
+
+// Decompose decomposes given snowflake id to timestamp, node id and sequence, using the epoch and node bits of Snowflake.
+func (s *Snowflake) Decompose(id int64) (t time.Time, node int64, seq int64) {
+	ms := id>>snowflakeNodeSeqBits + s.epochMs
+	t = time.Unix(ms/1e3, (ms%1e3)*int64(time.Millisecond))
+	node = id >> s.seqBits & (1<<s.nodeBits - 1)
+	seq = id & (1<<s.seqBits - 1)
+	return t, node, seq
+}
@@ -0,0 +1,289 @@
+package xstring
+
+import (
+	"encoding/json"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUUID(t *testing.T) {
+	t.Run("NewUUIDv4", func(t *testing.T) {
+		set := make(map[UUID]bool)
+		for i := 0; i < 1000; i++ {
+			u, err := NewUUIDv4()
+			xtesting.Nil(t, err)
+			xtesting.Equal(t, u.Version(), 4)
+			xtesting.Equal(t, u[8]&0xc0, byte(0x80))
+			xtesting.True(t, IsValidUUID(u.String()))
+			xtesting.False(t, set[u])
+			set[u] = true
+		}
+		u, _ := NewUUIDv4()
+		xtesting.Equal(t, u.Time(), time.Time{})
+	})
+
+	t.Run("NewUUIDv7", func(t *testing.T) {
+		now := time.Now()
+		uuids := make([]string, 0, 5000)
+		for i := 0; i < 5000; i++ {
+			u, err := NewUUIDv7()
+			xtesting.Nil(t, err)
+			xtesting.Equal(t, u.Version(), 7)
+			xtesting.Equal(t, u[8]&0xc0, byte(0x80))
+			xtesting.True(t, u.Time().Sub(now) > -time.Millisecond)
+			uuids = append(uuids, u.String())
+		}
+		xtesting.True(t, sort.StringsAreSorted(uuids))
+		for i := 1; i < len(uuids); i++ {
+			xtesting.NotEqual(t, uuids[i], uuids[i-1])
+		}
+
+		// counter overflow
+		fixed := time.Unix(1700000000, 0)
+		_uuidV7.mu.Lock()
+		_uuidV7.now, _uuidV7.lastMs, _uuidV7.seq = func() time.Time { return fixed }, fixed.UnixNano()/1e6, 0x0fff
+		_uuidV7.mu.Unlock()
+		u, _ := NewUUIDv7()
+		xtesting.Equal(t, u.Time(), fixed.Add(time.Millisecond))
+		xtesting.Equal(t, u[6], byte(0x70))
+		xtesting.Equal(t, u[7], byte(0x00))
+		_uuidV7.mu.Lock()
+		_uuidV7.now = time.Now
+		_uuidV7.mu.Unlock()
+	})
+
+	t.Run("ParseUUID", func(t *testing.T) {
+		want := UUID{0x01, 0x8f, 0x3c, 0x7e, 0x2a, 0x4b, 0x7c, 0xde, 0x8f, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd}
+		for _, tc := range []struct {
+			give    string
+			want    UUID
+			wantErr bool
+		}{
+			{"018f3c7e-2a4b-7cde-8f01-23456789abcd", want, false},
+			{"018F3C7E-2A4B-7CDE-8F01-23456789ABCD", want, false},
+			{"urn:uuid:018f3c7e-2a4b-7cde-8f01-23456789abcd", want, false},
+			{"URN:UUID:018f3c7e-2a4b-7cde-8f01-23456789abcd", want, false},
+			{"{018f3c7e-2a4b-7cde-8f01-23456789abcd}", want, false},
+			{"018f3c7e2a4b7cde8f0123456789abcd", want, false},
+			{"00000000-0000-0000-0000-000000000000", NilUUID, false},
+			{"", NilUUID, true},
+			{"018f3c7e-2a4b-7cde-8f01-23456789abc", NilUUID, true},
+			{"018f3c7e-2a4b-7cde-8f01-23456789abcde", NilUUID, true},
+			{"018f3c7e+2a4b-7cde-8f01-23456789abcd", NilUUID, true},
+			{"018f3c7e-2a4b-7cde-8f01-23456789abcg", NilUUID, true},
+			{"{018f3c7e-2a4b-7cde-8f01-23456789abcd", NilUUID, true},
+			{"uuid:018f3c7e-2a4b-7cde-8f01-23456789abcd", NilUUID, true},
+		} {
+			u, err := ParseUUID(tc.give)
+			xtesting.Equal(t, err != nil, tc.wantErr)
+			xtesting.Equal(t, IsValidUUID(tc.give), !tc.wantErr)
+			xtesting.Equal(t, u, tc.want)
+			if !tc.wantErr {
+				xtesting.Equal(t, u.String(), tc.want.String())
+			}
+		}
+		xtesting.Equal(t, want.String(), "018f3c7e-2a4b-7cde-8f01-23456789abcd")
+		xtesting.Equal(t, want.Version(), 7)
+		xtesting.Equal(t, want.Time().UnixNano()/1e6, int64(0x018f3c7e2a4b))
+		xtesting.Equal(t, NilUUID.String(), "00000000-0000-0000-0000-000000000000")
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		type s struct {
+			ID UUID `json:"id"`
+		}
+		u, _ := ParseUUID("018f3c7e-2a4b-7cde-8f01-23456789abcd")
+		bs, err := json.Marshal(&s{ID: u})
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, string(bs), `{"id":"018f3c7e-2a4b-7cde-8f01-23456789abcd"}`)
+		v := s{}
+		xtesting.Nil(t, json.Unmarshal(bs, &v))
+		xtesting.Equal(t, v.ID, u)
+		xtesting.NotNil(t, json.Unmarshal([]byte(`{"id":"xxx"}`), &v))
+	})
+}
+
+func TestULID(t *testing.T) {
+	t.Run("NewULID", func(t *testing.T) {
+		now := time.Now()
+		set := make(map[ULID]bool)
+		for i := 0; i < 1000; i++ {
+			u, err := NewULID()
+			xtesting.Nil(t, err)
+			xtesting.Equal(t, len(u.String()), 26)
+			xtesting.True(t, u.Time().Sub(now) > -time.Millisecond)
+			xtesting.False(t, set[u])
+			set[u] = true
+		}
+	})
+
+	t.Run("monotonic", func(t *testing.T) {
+		g := NewULIDGenerator(true)
+		fixed := time.Unix(1700000000, 0)
+		g.now = func() time.Time { return fixed }
+		first, err := g.New()
+		xtesting.Nil(t, err)
+		last := first
+		for i := 0; i < 1000; i++ {
+			u, err := g.New()
+			xtesting.Nil(t, err)
+			xtesting.True(t, u.String() > last.String())
+			xtesting.Equal(t, u.Time(), fixed)
+			last = u
+		}
+		xtesting.Equal(t, last[15]-first[15], byte(1000%256))
+
+		// clock moves backwards
+		g.now = func() time.Time { return fixed.Add(-time.Second) }
+		u, err := g.New()
+		xtesting.Nil(t, err)
+		xtesting.True(t, u.String() > last.String())
+		xtesting.Equal(t, u.Time(), fixed)
+
+		// overflow
+		for i := 6; i < 16; i++ {
+			g.last[i] = 0xff
+		}
+		_, err = g.New()
+		xtesting.Equal(t, err, errULIDOverflow)
+
+		// next millisecond
+		g.now = func() time.Time { return fixed.Add(time.Millisecond) }
+		u, err = g.New()
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, u.Time(), fixed.Add(time.Millisecond))
+	})
+
+	t.Run("ParseULID", func(t *testing.T) {
+		for _, tc := range []struct {
+			give    string
+			want    string
+			wantErr bool
+		}{
+			{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+			{"01arz3ndektsv4rrffq69g5fav", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+			{"0IARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+			{"OLARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+			{"00000000000000000000000000", "00000000000000000000000000", false},
+			{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+			{"", "", true},
+			{"01ARZ3NDEKTSV4RRFFQ69G5FA", "", true},
+			{"01ARZ3NDEKTSV4RRFFQ69G5FAVV", "", true},
+			{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "", true},
+			{"01ARZ3NDEKTSV4RRFFQ69G5FAU", "", true},
+			{"01ARZ3NDEKTSV4RRFFQ69G5FA-", "", true},
+		} {
+			u, err := ParseULID(tc.give)
+			xtesting.Equal(t, err != nil, tc.wantErr)
+			if !tc.wantErr {
+				xtesting.Equal(t, u.String(), tc.want)
+			}
+		}
+		u, _ := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+		xtesting.Equal(t, u.Time().UnixNano()/1e6, int64(1469922850259))
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		u, _ := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+		bs, err := json.Marshal([]ULID{u})
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, string(bs), `["01ARZ3NDEKTSV4RRFFQ69G5FAV"]`)
+		var v []ULID
+		xtesting.Nil(t, json.Unmarshal(bs, &v))
+		xtesting.Equal(t, v, []ULID{u})
+		xtesting.NotNil(t, json.Unmarshal([]byte(`["xxx"]`), &v))
+	})
+}
+
+func TestSnowflake(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("NewSnowflake", func(t *testing.T) {
+		for _, tc := range []struct {
+			giveEpoch    time.Time
+			giveNodeBits uint8
+			giveNode     int64
+			wantErr      error
+		}{
+			{epoch, 10, 0, nil},
+			{epoch, 10, 1023, nil},
+			{epoch, 0, 0, nil},
+			{epoch, 21, 0, nil},
+			{epoch, 22, 0, errSnowflakeNodeBits},
+			{epoch, 10, 1024, errSnowflakeNodeRange},
+			{epoch, 10, -1, errSnowflakeNodeRange},
+			{epoch, 0, 1, errSnowflakeNodeRange},
+			{time.Now().Add(time.Hour), 10, 0, errSnowflakeEpoch},
+		} {
+			_, err := NewSnowflake(tc.giveEpoch, tc.giveNodeBits, tc.giveNode)
+			xtesting.Equal(t, err, tc.wantErr)
+		}
+	})
+
+	t.Run("Next", func(t *testing.T) {
+		s, _ := NewSnowflake(epoch, 10, 5)
+		fixed := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		s.now = func() time.Time { return fixed }
+		for i := 0; i < 10; i++ {
+			id, err := s.Next()
+			xtesting.Nil(t, err)
+			tt, node, seq := s.Decompose(id)
+			xtesting.Equal(t, tt.UnixNano(), fixed.UnixNano())
+			xtesting.Equal(t, node, int64(5))
+			xtesting.Equal(t, seq, int64(i))
+		}
+
+		// clock moves backwards
+		s.now = func() time.Time { return fixed.Add(-time.Millisecond) }
+		_, err := s.Next()
+		xtesting.Equal(t, err, errSnowflakeClockBackward)
+
+		// sequence is exhausted
+		s.seq = 1<<12 - 1
+		calls := 0
+		s.now = func() time.Time {
+			calls++
+			if calls <= 2 {
+				return fixed
+			}
+			return fixed.Add(time.Millisecond)
+		}
+		id, err := s.Next()
+		xtesting.Nil(t, err)
+		tt, node, seq := s.Decompose(id)
+		xtesting.Equal(t, tt.UnixNano(), fixed.Add(time.Millisecond).UnixNano())
+		xtesting.Equal(t, node, int64(5))
+		xtesting.Equal(t, seq, int64(0))
+
+		// timestamp overflows
+		s.now = func() time.Time { return epoch.Add(time.Millisecond * (1 << 41)) }
+		_, err = s.Next()
+		xtesting.Equal(t, err, errSnowflakeTimeOverflow)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		s, _ := NewSnowflake(epoch, 4, 15)
+		mu := sync.Mutex{}
+		set := make(map[int64]bool)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 2000; j++ {
+					id, err := s.Next()
+					xtesting.Nil(t, err)
+					xtesting.True(t, id > 0)
+					mu.Lock()
+					set[id] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		xtesting.Equal(t, len(set), 8*2000)
+	})
+}
@@ -0,0 +1,312 @@
+package xstring
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// inflectRule represents a pluralization or singularization rule, that is a regexp and its replacement.
+type inflectRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// inflections represents the English inflection rules, which can be extended by AddPluralRule, AddSingularRule,
+// AddIrregular and AddUncountable. Note that the rules added later take precedence.
+type inflections struct {
+	mu           sync.RWMutex
+	plurals      []inflectRule
+	singulars    []inflectRule
+	irregulars   map[string]string // singular -> plural
+	irregularsR  map[string]string // plural -> singular
+	uncountables map[string]bool
+}
+
+var _inflections = newDefaultInflections()
+
+// newDefaultInflections creates the default inflections, the rules are almost the same as Rails' ActiveSupport.
+func newDefaultInflections() *inflections {
+	in := &inflections{
+		irregulars:   make(map[string]string),
+		irregularsR:  make(map[string]string),
+		uncountables: make(map[string]bool),
+	}
+	for _, r := range [][2]string{
+		{`$`, `s`},
+		{`s$`, `s`},
+		{`^(ax|test)is$`, `${1}es`},
+		{`(octop|vir)us$`, `${1}i`},
+		{`(octop|vir)i$`, `${1}i`},
+		{`(alias|status|campus)$`, `${1}es`},
+		{`(bu)s$`, `${1}ses`},
+		{`(buffal|tomat|potat|her|ech)o$`, `${1}oes`},
+		{`([ti])um$`, `${1}a`},
+		{`([ti])a$`, `${1}a`},
+		{`sis$`, `ses`},
+		{`(?:([^f])fe|([lr])f)$`, `${1}${2}ves`},
+		{`(hive)$`, `${1}s`},
+		{`([^aeiouy]|qu)y$`, `${1}ies`},
+		{`(x|ch|ss|sh)$`, `${1}es`},
+		{`(matr|vert|ind)(?:ix|ex)$`, `${1}ices`},
+		{`^(m|l)ouse$`, `${1}ice`},
+		{`^(m|l)ice$`, `${1}ice`},
+		{`^(ox)$`, `${1}en`},
+		{`^(oxen)$`, `${1}`},
+		{`(quiz)$`, `${1}zes`},
+	} {
+		in.addPlural(r[0], r[1])
+	}
+	for _, r := range [][2]string{
+		{`s$`, ``},
+		{`(ss)$`, `${1}`},
+		{`(n)ews$`, `${1}ews`},
+		{`([ti])a$`, `${1}um`},
+		{`((a)naly|(b)a|(d)iagno|(p)arenthe|(p)rogno|(s)ynop|(t)he)(sis|ses)$`, `${1}sis`},
+		{`(^analy)(sis|ses)$`, `${1}sis`},
+		{`([^f])ves$`, `${1}fe`},
+		{`(hive)s$`, `${1}`},
+		{`(tive)s$`, `${1}`},
+		{`([lr])ves$`, `${1}f`},
+		{`([^aeiouy]|qu)ies$`, `${1}y`},
+		{`(s)eries$`, `${1}eries`},
+		{`(m)ovies$`, `${1}ovie`},
+		{`(x|ch|ss|sh)es$`, `${1}`},
+		{`^(m|l)ice$`, `${1}ouse`},
+		{`(bus)(es)?$`, `${1}`},
+		{`(o)es$`, `${1}`},
+		{`(shoe)s$`, `${1}`},
+		{`(cris|test)(is|es)$`, `${1}is`},
+		{`^(a)x[ie]s$`, `${1}xis`},
+		{`(octop|vir)(us|i)$`, `${1}us`},
+		{`(alias|status|campus)(es)?$`, `${1}`},
+		{`^(ox)en`, `${1}`},
+		{`(vert|ind)ices$`, `${1}ex`},
+		{`(matr)ices$`, `${1}ix`},
+		{`(quiz)zes$`, `${1}`},
+		{`(database)s$`, `${1}`},
+	} {
+		in.addSingular(r[0], r[1])
+	}
+	for _, r := range [][2]string{
+		{"person", "people"},
+		{"man", "men"},
+		{"woman", "women"},
+		{"child", "children"},
+		{"sex", "sexes"},
+		{"move", "moves"},
+		{"zombie", "zombies"},
+		{"foot", "feet"},
+		{"tooth", "teeth"},
+		{"goose", "geese"},
+	} {
+		in.addIrregular(r[0], r[1])
+	}
+	for _, w := range []string{"equipment", "information", "rice", "money", "species", "series", "fish", "sheep", "jeans", "police", "news"} {
+		in.uncountables[w] = true
+	}
+	return in
+}
+
+func (in *inflections) addPlural(pattern, replacement string) {
+	in.plurals = append(in.plurals, inflectRule{pattern: regexp.MustCompile("(?i)" + pattern), replacement: replacement})
+}
+
+func (in *inflections) addSingular(pattern, replacement string) {
+	in.singulars = append(in.singulars, inflectRule{pattern: regexp.MustCompile("(?i)" + pattern), replacement: replacement})
+}
+
+func (in *inflections) addIrregular(singular, plural string) {
+	singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+	delete(in.uncountables, singular)
+	delete(in.uncountables, plural)
+	in.irregulars[singular] = plural
+	in.irregularsR[plural] = singular
+}
+
+// AddPluralRule adds a pluralization rule, the pattern is a case-insensitive regexp, and the replacement can contain `${1}`
+// to refer to the submatch. Note that the rules added later take precedence, and this function panics if the pattern is
+// invalid.
+//
+// Example:
+// 	AddPluralRule(`(cact)us$`, `${1}i`)
+// 	Pluralize("cactus") // => cacti
+func AddPluralRule(pattern, replacement string) {
+	_inflections.mu.Lock()
+	defer _inflections.mu.Unlock()
+	_inflections.addPlural(pattern, replacement)
+}
+
+// AddSingularRule adds a singularization rule, see AddPluralRule for details.
+func AddSingularRule(pattern, replacement string) {
+	_inflections.mu.Lock()
+	defer _inflections.mu.Unlock()
+	_inflections.addSingular(pattern, replacement)
+}
+
+// AddIrregular adds an irregular word pair, which is used in both Pluralize and Singularize.
+//
+// Example:
+// 	AddIrregular("octopus", "octopodes")
+func AddIrregular(singular, plural string) {
+	_inflections.mu.Lock()
+	defer _inflections.mu.Unlock()
+	_inflections.addIrregular(singular, plural)
+}
+
+// AddUncountable adds uncountable words, which will be returned directly by Pluralize and Singularize.
+func AddUncountable(words ...string) {
+	_inflections.mu.Lock()
+	defer _inflections.mu.Unlock()
+	for _, w := range words {
+		_inflections.uncountables[strings.ToLower(w)] = true
+	}
+}
+
+// lastWordIndex returns the start index of the last word in s, the words are separated by non-letter characters or the
+// lower-to-upper case change.
+func lastWordIndex(s string) int {
+	runes := []rune(s)
+	for i := len(runes) - 1; i > 0; i-- {
+		if !unicode.IsLetter(runes[i-1]) || (unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1])) {
+			return len(string(runes[:i]))
+		}
+	}
+	return 0
+}
+
+// matchCase converts word to have the same case style as the template, that is all uppercase, capitalized or unchanged.
+func matchCase(word, template string) string {
+	if strings.ToUpper(template) == template && strings.ToLower(template) != template {
+		return strings.ToUpper(word)
+	}
+	if r := []rune(template); len(r) > 0 && unicode.IsUpper(r[0]) {
+		return Capitalize(word)
+	}
+	return word
+}
+
+// inflect is the implementation of Pluralize and Singularize.
+func inflect(word string, rules []inflectRule, irregulars map[string]string, others map[string]string) string {
+	if strings.TrimSpace(word) == "" {
+		return word
+	}
+	idx := lastWordIndex(word)
+	prefix, last := word[:idx], word[idx:]
+	lower := strings.ToLower(last)
+	if _inflections.uncountables[lower] {
+		return word
+	}
+	if replaced, ok := irregulars[lower]; ok {
+		return prefix + matchCase(replaced, last)
+	}
+	if _, ok := others[lower]; ok {
+		return word // already in the target form
+	}
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(last) {
+			return prefix + matchCase(rules[i].pattern.ReplaceAllString(last, rules[i].replacement), last)
+		}
+	}
+	return word
+}
+
+// Pluralize returns the plural form of given word, note that only the last word will be converted if given string is
+// combined by several words, such as `user_profile` and `UserProfile`.
+//
+// Example:
+// 	Pluralize("post")         // => posts
+// 	Pluralize("octopus")      // => octopi
+// 	Pluralize("sheep")        // => sheep
+// 	Pluralize("SalesPerson")  // => SalesPeople
+// 	Pluralize("user_profile") // => user_profiles
+func Pluralize(word string) string {
+	_inflections.mu.RLock()
+	defer _inflections.mu.RUnlock()
+	return inflect(word, _inflections.plurals, _inflections.irregulars, _inflections.irregularsR)
+}
+
+// Singularize returns the singular form of given word, this is the reverse of Pluralize.
+//
+// Example:
+// 	Singularize("posts")    // => post
+// 	Singularize("octopi")   // => octopus
+// 	Singularize("people")   // => person
+// 	Singularize("CHILDREN") // => CHILD
+func Singularize(word string) string {
+	_inflections.mu.RLock()
+	defer _inflections.mu.RUnlock()
+	return inflect(word, _inflections.singulars, _inflections.irregularsR, _inflections.irregulars)
+}
+
+// Ordinal returns the ordinal suffix of given number, that is st, nd, rd or th.
+func Ordinal(n int) string {
+	if n < 0 {
+		n = -n
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// Ordinalize returns the ordinal string of given number, such as 1st, 2nd, 3rd, 11th and 21st.
+func Ordinalize(n int) string {
+	return strconv.Itoa(n) + Ordinal(n)
+}
+
+// humanizeWords splits s to lowercase words and removes the trailing `id` word.
+func humanizeWords(s string) []string {
+	words := SplitToWords(s)
+	if len(words) > 1 && strings.ToLower(words[len(words)-1]) == "id" {
+		words = words[:len(words)-1]
+	}
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return words
+}
+
+// Humanize converts given identifier to a human-readable string, that is splitting words, removing the trailing `id`,
+// and capitalizing the first word.
+//
+// Example:
+// 	Humanize("user_id")         // => User
+// 	Humanize("employee_salary") // => Employee salary
+// 	Humanize("createdAt")       // => Created at
+func Humanize(s string) string {
+	return Capitalize(strings.Join(humanizeWords(s), " "))
+}
+
+// Titleize converts given identifier to a title string, that is Humanize and capitalizing all the words.
+//
+// Example:
+// 	Titleize("man_from_the_boondocks") // => Man From The Boondocks
+// 	Titleize("author_id")              // => Author
+func Titleize(s string) string {
+	words := humanizeWords(s)
+	for i, w := range words {
+		words[i] = Capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// Tableize converts given type name to a table name, that is SnakeCase and Pluralize.
+//
+// Example:
+// 	Tableize("RawScaledScorer") // => raw_scaled_scorers
+// 	Tableize("Person")          // => people
+func Tableize(typeName string) string {
+	return Pluralize(SnakeCase(typeName))
+}
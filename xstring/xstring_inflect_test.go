@@ -0,0 +1,176 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"sync"
+	"testing"
+)
+
+func TestPluralizeAndSingularize(t *testing.T) {
+	for _, tc := range []struct {
+		giveSingular string
+		givePlural   string
+	}{
+		{"post", "posts"},
+		{"status", "statuses"},
+		{"bus", "buses"},
+		{"quiz", "quizzes"},
+		{"box", "boxes"},
+		{"church", "churches"},
+		{"city", "cities"},
+		{"day", "days"},
+		{"query", "queries"},
+		{"wife", "wives"},
+		{"wolf", "wolves"},
+		{"half", "halves"},
+		{"tomato", "tomatoes"},
+		{"photo", "photos"},
+		{"analysis", "analyses"},
+		{"datum", "data"},
+		{"medium", "media"},
+		{"matrix", "matrices"},
+		{"index", "indices"},
+		{"axis", "axes"},
+		{"octopus", "octopi"},
+		{"mouse", "mice"},
+		{"ox", "oxen"},
+		{"movie", "movies"},
+		{"database", "databases"},
+		{"person", "people"},
+		{"child", "children"},
+		{"woman", "women"},
+		{"foot", "feet"},
+		{"sheep", "sheep"},
+		{"information", "information"},
+		{"news", "news"},
+		{"Person", "People"},
+		{"CITY", "CITIES"},
+		{"Child", "Children"},
+		{"user_profile", "user_profiles"},
+		{"UserCategory", "UserCategories"},
+		{"SalesPerson", "SalesPeople"},
+		{"big-fish", "big-fish"},
+		{"中文", "中文s"},
+	} {
+		xtesting.Equal(t, Pluralize(tc.giveSingular), tc.givePlural)
+		xtesting.Equal(t, Singularize(tc.givePlural), tc.giveSingular)
+	}
+
+	for _, tc := range []struct {
+		give     string
+		wantFunc func(string) string
+		want     string
+	}{
+		{"", Pluralize, ""},
+		{" ", Pluralize, " "},
+		{"", Singularize, ""},
+		{"people", Pluralize, "people"},
+		{"person", Singularize, "person"},
+		{"posts", Pluralize, "posts"},
+		{"post", Singularize, "post"},
+		{"user_1", Pluralize, "user_1s"},
+	} {
+		xtesting.Equal(t, tc.wantFunc(tc.give), tc.want)
+	}
+}
+
+func TestInflectionRules(t *testing.T) {
+	original := _inflections
+	defer func() { _inflections = original }()
+	_inflections = newDefaultInflections()
+
+	xtesting.Equal(t, Pluralize("cactus"), "cactus")
+	AddPluralRule(`(cact)us$`, `${1}i`)
+	AddSingularRule(`(cact)i$`, `${1}us`)
+	xtesting.Equal(t, Pluralize("cactus"), "cacti")
+	xtesting.Equal(t, Singularize("Cacti"), "Cactus")
+
+	AddIrregular("Sheep", "Sheeps")
+	xtesting.Equal(t, Pluralize("sheep"), "sheeps")
+	xtesting.Equal(t, Singularize("sheeps"), "sheep")
+
+	AddUncountable("Code", "metadata")
+	xtesting.Equal(t, Pluralize("code"), "code")
+	xtesting.Equal(t, Pluralize("source_code"), "source_code")
+	xtesting.Equal(t, Singularize("metadata"), "metadata")
+
+	xtesting.Panic(t, func() { AddPluralRule(`(`, ``) })
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				AddUncountable("equipment")
+				xtesting.Equal(t, Pluralize("cactus"), "cacti")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestOrdinalize(t *testing.T) {
+	for _, tc := range []struct {
+		give       int
+		wantSuffix string
+		want       string
+	}{
+		{0, "th", "0th"},
+		{1, "st", "1st"},
+		{2, "nd", "2nd"},
+		{3, "rd", "3rd"},
+		{4, "th", "4th"},
+		{11, "th", "11th"},
+		{12, "th", "12th"},
+		{13, "th", "13th"},
+		{21, "st", "21st"},
+		{22, "nd", "22nd"},
+		{101, "st", "101st"},
+		{111, "th", "111th"},
+		{1002, "nd", "1002nd"},
+		{-1, "st", "-1st"},
+		{-11, "th", "-11th"},
+	} {
+		xtesting.Equal(t, Ordinal(tc.give), tc.wantSuffix)
+		xtesting.Equal(t, Ordinalize(tc.give), tc.want)
+	}
+}
+
+func TestHumanizeAndTitleize(t *testing.T) {
+	for _, tc := range []struct {
+		give         string
+		wantHumanize string
+		wantTitleize string
+	}{
+		{"", "", ""},
+		{"user_id", "User", "User"},
+		{"id", "Id", "Id"},
+		{"employee_salary", "Employee salary", "Employee Salary"},
+		{"createdAt", "Created at", "Created At"},
+		{"AuthorID", "Author", "Author"},
+		{"man_from_the_boondocks", "Man from the boondocks", "Man From The Boondocks"},
+		{"  x-men: the last stand ", "X men: the last stand", "X Men: The Last Stand"},
+	} {
+		xtesting.Equal(t, Humanize(tc.give), tc.wantHumanize)
+		xtesting.Equal(t, Titleize(tc.give), tc.wantTitleize)
+	}
+}
+
+func TestTableize(t *testing.T) {
+	for _, tc := range []struct {
+		give string
+		want string
+	}{
+		{"", ""},
+		{"User", "users"},
+		{"Person", "people"},
+		{"RawScaledScorer", "raw_scaled_scorers"},
+		{"UserProfile", "user_profiles"},
+		{"HTTPProxy", "http_proxies"},
+		{"ProductCategory", "product_categories"},
+		{"Equipment", "equipment"},
+	} {
+		xtesting.Equal(t, Tableize(tc.give), tc.want)
+	}
+}
@@ -0,0 +1,303 @@
+package xstring
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// MaskRule represents a masking rule used by Masker, which detects sensitive text by Pattern and masks each match by Mask.
+type MaskRule struct {
+	// Name represents the name of this rule.
+	Name string
+
+	// Pattern represents the regexp used to detect sensitive text.
+	Pattern *regexp.Regexp
+
+	// Mask represents the function used to mask a matched text, using given mask rune.
+	Mask func(s string, mask rune) string
+}
+
+var (
+	// EmailMaskRule masks the local part of email address, see MaskEmail.
+	EmailMaskRule = MaskRule{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+		Mask:    MaskEmail,
+	}
+
+	// PhoneMaskRule masks the middle digits of phone number, see MaskPhone.
+	PhoneMaskRule = MaskRule{
+		Name:    "phone",
+		Pattern: regexp.MustCompile(`(?:\+\d{1,3}[ \-]?)?\b\d{3,4}[ \-]?\d{3,4}[ \-]?\d{4}\b`),
+		Mask:    MaskPhone,
+	}
+
+	// CreditCardMaskRule masks the credit card number except the last 4 digits, see MaskCreditCard. Note that only the
+	// numbers which pass the Luhn check will be masked.
+	CreditCardMaskRule = MaskRule{
+		Name:    "credit_card",
+		Pattern: regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`),
+		Mask:    MaskCreditCard,
+	}
+
+	// IPv4MaskRule masks the last octet of IPv4 address, see MaskIPv4.
+	IPv4MaskRule = MaskRule{
+		Name:    "ipv4",
+		Pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`),
+		Mask:    MaskIPv4,
+	}
+
+	// JWTMaskRule masks the signature part of JWT, see MaskJWT.
+	JWTMaskRule = MaskRule{
+		Name:    "jwt",
+		Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]*\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`),
+		Mask:    MaskJWT,
+	}
+)
+
+// DefaultMaskRules returns the built-in MaskRule slice, that is JWTMaskRule, EmailMaskRule, CreditCardMaskRule, PhoneMaskRule
+// and IPv4MaskRule, in the order of being applied.
+func DefaultMaskRules() []MaskRule {
+	return []MaskRule{JWTMaskRule, EmailMaskRule, CreditCardMaskRule, PhoneMaskRule, IPv4MaskRule}
+}
+
+// maskRunes replaces the runes of s whose index in [from, to) to mask rune, here the index is counted in runes.
+func maskRunes(s string, mask rune, from, to int) string {
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+	idx := 0
+	for _, r := range s {
+		if idx >= from && idx < to {
+			sb.WriteRune(mask)
+		} else {
+			sb.WriteRune(r)
+		}
+		idx++
+	}
+	return sb.String()
+}
+
+// maskDigits replaces the digits of s whose index in [from, to) to mask rune, here the index is counted in digits, and
+// other characters such as separators are kept.
+func maskDigits(s string, mask rune, from, to int) string {
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+	idx := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			if idx >= from && idx < to {
+				r = mask
+			}
+			idx++
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// countDigits returns the number of digits in s.
+func countDigits(s string) int {
+	cnt := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// MaskEmail masks the local part of given email address, the first and the last characters will be kept if the local part
+// is longer than 2 characters, otherwise only the first character will be kept. Note that s will be returned directly if it
+// is not an email address.
+//
+// Example:
+// 	MaskEmail("john.doe@example.com", '*') // => j******e@example.com
+// 	MaskEmail("ab@example.com", '*')       // => a*@example.com
+func MaskEmail(s string, mask rune) string {
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 {
+		return s
+	}
+	local := s[:at]
+	length := utf8.RuneCountInString(local)
+	if length > 2 {
+		local = maskRunes(local, mask, 1, length-1)
+	} else {
+		local = maskRunes(local, mask, 1, length)
+	}
+	return local + s[at:]
+}
+
+// MaskPhone masks the middle digits of given phone number, the first 3 and the last 4 digits will be kept, and separators
+// are kept. Note that s will be returned directly if it contains less than 8 digits.
+//
+// Example:
+// 	MaskPhone("13812345678", '*')     // => 138****5678
+// 	MaskPhone("+1 415-555-2671", '*') // => +1 41*-***-2671
+func MaskPhone(s string, mask rune) string {
+	cnt := countDigits(s)
+	if cnt < 8 {
+		return s
+	}
+	return maskDigits(s, mask, 3, cnt-4)
+}
+
+// MaskCreditCard masks the digits of given credit card number except the last 4 digits, and separators are kept. Note
+// that s will be returned directly if it does not pass the Luhn check, or its digits count is not in [13, 19].
+//
+// Example:
+// 	MaskCreditCard("4111 1111 1111 1111", '*') // => **** **** **** 1111
+func MaskCreditCard(s string, mask rune) string {
+	cnt := countDigits(s)
+	if cnt < 13 || cnt > 19 || !luhnCheck(s) {
+		return s
+	}
+	return maskDigits(s, mask, 0, cnt-4)
+}
+
+// luhnCheck checks whether the digits in s pass the Luhn algorithm, non-digit characters are ignored.
+func luhnCheck(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// MaskIPv4 masks the last octet of given IPv4 address. Note that s will be returned directly if it is not an IPv4 address.
+//
+// Example:
+// 	MaskIPv4("192.168.1.123", '*') // => 192.168.1.***
+func MaskIPv4(s string, mask rune) string {
+	if strings.Count(s, ".") != 3 {
+		return s
+	}
+	dot := strings.LastIndexByte(s, '.')
+	return s[:dot+1] + strings.Repeat(string(mask), len(s)-dot-1)
+}
+
+// MaskJWT masks the signature part of given JWT, that is the part after the second dot. Note that s will be returned
+// directly if it is not combined by three parts.
+//
+// Example:
+// 	MaskJWT("eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.abcdef", '*') // => eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.******
+func MaskJWT(s string, mask rune) string {
+	if strings.Count(s, ".") != 2 {
+		return s
+	}
+	dot := strings.LastIndexByte(s, '.')
+	return s[:dot+1] + strings.Repeat(string(mask), len(s)-dot-1)
+}
+
+// Masker represents a masker which masks sensitive text in arbitrary text using a set of MaskRule.
+type Masker struct {
+	mask  rune
+	rules []MaskRule
+}
+
+// NewMasker creates a Masker with given mask rune and MaskRule slice, rules will be applied in the given order, and the
+// result of DefaultMaskRules will be used if no rule is given.
+func NewMasker(mask rune, rules ...MaskRule) *Masker {
+	if len(rules) == 0 {
+		rules = DefaultMaskRules()
+	}
+	copied := make([]MaskRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Pattern != nil && rule.Mask != nil {
+			copied = append(copied, rule)
+		}
+	}
+	return &Masker{mask: mask, rules: copied}
+}
+
+// Mask masks all the sensitive text detected by rules in s.
+//
+// Example:
+// 	m := NewMasker('*')
+// 	m.Mask("user john.doe@example.com login from 10.0.0.12") // => user j******e@example.com login from 10.0.0.**
+func (m *Masker) Mask(s string) string {
+	for _, rule := range m.rules {
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(matched string) string {
+			return rule.Mask(matched, m.mask)
+		})
+	}
+	return s
+}
+
+// MaskWriter represents an io.Writer wrapper which masks the written text line by line using Masker, is created by
+// NewMaskWriter. Note that the incomplete line will be buffered until a newline is written or Flush is called.
+type MaskWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	masker *Masker
+	buf    []byte
+}
+
+// NewMaskWriter creates a MaskWriter with given io.Writer and Masker, the Masker with default rules and '*' mask rune will
+// be used if masker is nil.
+//
+// Example:
+// 	w := NewMaskWriter(os.Stdout, nil)
+// 	log.SetOutput(w)
+func NewMaskWriter(w io.Writer, masker *Masker) *MaskWriter {
+	if masker == nil {
+		masker = NewMasker('*')
+	}
+	return &MaskWriter{w: w, masker: masker}
+}
+
+// Write implements io.Writer, it masks and writes the complete lines in p to the underlying io.Writer, and buffers the
+// incomplete line. Note that the returned n is len(p) if no error happened.
+func (m *MaskWriter) Write(p []byte) (n int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.buf = append(m.buf, p...)
+	idx := bytes.LastIndexByte(m.buf, '\n')
+	if idx == -1 {
+		return len(p), nil
+	}
+	lines := string(m.buf[:idx+1])
+	m.buf = append(m.buf[:0], m.buf[idx+1:]...)
+
+	masked := make([]string, 0)
+	for _, line := range strings.SplitAfter(lines, "\n") {
+		if line != "" {
+			masked = append(masked, m.masker.Mask(line))
+		}
+	}
+	if _, err = io.WriteString(m.w, strings.Join(masked, "")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush masks and writes the buffered incomplete line to the underlying io.Writer.
+func (m *MaskWriter) Flush() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.buf) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(m.w, m.masker.Mask(string(m.buf)))
+	m.buf = m.buf[:0]
+	return err
+}
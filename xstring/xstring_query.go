@@ -0,0 +1,716 @@
+package xstring
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// =====
+// query
+// =====
+
+// QueryPair represents a key-value pair in query string.
+type QueryPair struct {
+	Key   string
+	Value string
+}
+
+// Query represents an ordered query string, which keeps the insertion order of pairs and the duplicate keys, is different
+// from url.Values. Note that Query is not safe for concurrent use.
+type Query struct {
+	pairs []QueryPair
+}
+
+var (
+	errInvalidQueryEscape  = errors.New("xstring: invalid escape in query string")
+	errInvalidQueryBracket = errors.New("xstring: invalid bracket notation in query key")
+	errQueryTypeConflict   = errors.New("xstring: conflicted types for the same key in query string")
+)
+
+// NewQuery creates an empty Query.
+func NewQuery() *Query {
+	return &Query{pairs: make([]QueryPair, 0)}
+}
+
+// ParseQuery parses given query string to Query, keeps the original order of keys and the duplicate keys. Note that the
+// leading `?` is ignored, empty segments are skipped, `+` is regarded as space, and a key without `=` has an empty value.
+//
+// Example:
+// 	ParseQuery("?b=2&a=1&b=3&c") // => b=2&a=1&b=3&c=
+func ParseQuery(s string) (*Query, error) {
+	q := NewQuery()
+	s = strings.TrimPrefix(s, "?")
+	for _, segment := range strings.Split(s, "&") {
+		if segment == "" {
+			continue
+		}
+		key, value := segment, ""
+		if idx := strings.IndexByte(segment, '='); idx != -1 {
+			key, value = segment[:idx], segment[idx+1:]
+		}
+		key, err1 := url.QueryUnescape(key)
+		value, err2 := url.QueryUnescape(value)
+		if err1 != nil || err2 != nil {
+			return nil, errInvalidQueryEscape
+		}
+		q.pairs = append(q.pairs, QueryPair{Key: key, Value: value})
+	}
+	return q, nil
+}
+
+// QueryFromValues creates a Query from url.Values, note that the keys are sorted since the order of map is random.
+func QueryFromValues(values url.Values) *Query {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	q := NewQuery()
+	for _, k := range keys {
+		for _, v := range values[k] {
+			q.Add(k, v)
+		}
+	}
+	return q
+}
+
+// OrderedMapLike represents an ordered map, such as xorderedmap.OrderedMap, which can be used to build Query in order.
+type OrderedMapLike interface {
+	Keys() []string
+	Get(key string) (interface{}, bool)
+}
+
+// QueryFromOrderedMap creates a Query from an ordered map (such as xorderedmap.OrderedMap), keeps the order of keys, and
+// the values are added by AddNested.
+//
+// Example:
+// 	m := xorderedmap.New()
+// 	m.Set("b", 1)
+// 	m.Set("a", []int{2, 3})
+// 	QueryFromOrderedMap(m) // => b=1&a[]=2&a[]=3
+func QueryFromOrderedMap(m OrderedMapLike) *Query {
+	q := NewQuery()
+	for _, k := range m.Keys() {
+		v, _ := m.Get(k)
+		q.AddNested(k, v)
+	}
+	return q
+}
+
+// Len returns the number of pairs in Query.
+func (q *Query) Len() int {
+	return len(q.pairs)
+}
+
+// Pairs returns a copy of all the pairs in Query, in insertion order.
+func (q *Query) Pairs() []QueryPair {
+	out := make([]QueryPair, len(q.pairs))
+	copy(out, q.pairs)
+	return out
+}
+
+// Keys returns the distinct keys in Query, in the order of their first occurrence.
+func (q *Query) Keys() []string {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, p := range q.pairs {
+		if !seen[p.Key] {
+			seen[p.Key] = true
+			keys = append(keys, p.Key)
+		}
+	}
+	return keys
+}
+
+// Has checks whether given key exists in Query.
+func (q *Query) Has(key string) bool {
+	for _, p := range q.pairs {
+		if p.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the first value of given key, returns empty string if the key does not exist.
+func (q *Query) Get(key string) string {
+	for _, p := range q.pairs {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// GetAll returns all the values of given key in order, returns empty slice if the key does not exist.
+func (q *Query) GetAll(key string) []string {
+	out := make([]string, 0)
+	for _, p := range q.pairs {
+		if p.Key == key {
+			out = append(out, p.Value)
+		}
+	}
+	return out
+}
+
+// Add appends a key-value pair to Query, returns the Query itself.
+func (q *Query) Add(key, value string) *Query {
+	q.pairs = append(q.pairs, QueryPair{Key: key, Value: value})
+	return q
+}
+
+// Set replaces the value of the first occurrence of given key and removes the others, or appends the pair if the key
+// does not exist, returns the Query itself.
+func (q *Query) Set(key, value string) *Query {
+	found := false
+	pairs := q.pairs[:0]
+	for _, p := range q.pairs {
+		if p.Key == key {
+			if found {
+				continue
+			}
+			found = true
+			p.Value = value
+		}
+		pairs = append(pairs, p)
+	}
+	q.pairs = pairs
+	if !found {
+		q.pairs = append(q.pairs, QueryPair{Key: key, Value: value})
+	}
+	return q
+}
+
+// Del removes all the pairs with given key, returns the Query itself.
+func (q *Query) Del(key string) *Query {
+	pairs := q.pairs[:0]
+	for _, p := range q.pairs {
+		if p.Key != key {
+			pairs = append(pairs, p)
+		}
+	}
+	q.pairs = pairs
+	return q
+}
+
+// Values returns the url.Values of Query, note that the order of keys is lost.
+func (q *Query) Values() url.Values {
+	values := url.Values{}
+	for _, p := range q.pairs {
+		values[p.Key] = append(values[p.Key], p.Value)
+	}
+	return values
+}
+
+// Encode encodes Query into url encoded form in insertion order, using url.QueryEscape for both keys and values.
+func (q *Query) Encode() string {
+	return q.EncodeWith(url.QueryEscape)
+}
+
+// EncodeWith encodes Query into url encoded form in insertion order with escapeFunc, see EncodeUrlValues for escapeFunc.
+func (q *Query) EncodeWith(escapeFunc func(string) string) string {
+	sb := strings.Builder{}
+	for _, p := range q.pairs {
+		key, value := p.Key, p.Value
+		if escapeFunc != nil {
+			key, value = escapeFunc(key), escapeFunc(value)
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("&")
+		}
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(value)
+	}
+	return sb.String()
+}
+
+// String returns the Query in url encoded form without escaping, which is used for debugging.
+func (q *Query) String() string {
+	return q.EncodeWith(nil)
+}
+
+// ================
+// bracket notation
+// ================
+
+// AddNested appends value with given key to Query using bracket notation used by PHP and Rails, returns the Query itself.
+// Here slice and array are expanded to `key[]=v`, map and OrderedMapLike are expanded to `key[k]=v` (the keys of map are
+// sorted), pointers are dereferenced (nil pointers are skipped) unless they implement fmt.Stringer, and other values are
+// formatted by fmt.Sprint.
+//
+// Example:
+// 	q.AddNested("a", map[string]interface{}{"b": []int{1, 2}, "c": "x"}) // => a[b][]=1&a[b][]=2&a[c]=x
+func (q *Query) AddNested(key string, value interface{}) *Query {
+	if om, ok := value.(OrderedMapLike); ok {
+		for _, k := range om.Keys() {
+			v, _ := om.Get(k)
+			q.AddNested(key+"["+k+"]", v)
+		}
+		return q
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+			return q.Add(key, string(val.Bytes())) // []byte
+		}
+		for i := 0; i < val.Len(); i++ {
+			q.AddNested(key+"[]", val.Index(i).Interface())
+		}
+	case reflect.Map:
+		keys := make([]string, 0, val.Len())
+		values := make(map[string]interface{}, val.Len())
+		for _, k := range val.MapKeys() {
+			ks := fmt.Sprint(k.Interface())
+			keys = append(keys, ks)
+			values[ks] = val.MapIndex(k).Interface()
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			q.AddNested(key+"["+k+"]", values[k])
+		}
+	case reflect.Ptr:
+		if val.IsNil() {
+			return q
+		}
+		if _, ok := value.(fmt.Stringer); ok {
+			return q.Add(key, fmt.Sprint(value))
+		}
+		return q.AddNested(key, val.Elem().Interface())
+	case reflect.Invalid:
+		q.Add(key, "")
+	default:
+		q.Add(key, fmt.Sprint(value))
+	}
+	return q
+}
+
+// splitBracketKey splits the key in bracket notation, such as `a[b][]` to [a, b, ""].
+func splitBracketKey(key string) ([]string, error) {
+	idx := strings.IndexByte(key, '[')
+	if idx == -1 {
+		return []string{key}, nil
+	}
+	if idx == 0 {
+		return nil, errInvalidQueryBracket
+	}
+	parts := []string{key[:idx]}
+	rest := key[idx:]
+	for len(rest) > 0 {
+		if rest[0] != '[' {
+			return nil, errInvalidQueryBracket
+		}
+		end := strings.IndexByte(rest, ']')
+		if end == -1 {
+			return nil, errInvalidQueryBracket
+		}
+		parts = append(parts, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return parts, nil
+}
+
+// Nested converts the Query in bracket notation to a nested map, where the values are string, []interface{} or
+// map[string]interface{}. Note that duplicate keys without brackets are also collected into []interface{}, and `a[][b]`
+// will be merged into the last map item of `a` if the map does not contain `b`, which is the same as Rails.
+//
+// Example:
+// 	q, _ := ParseQuery("a[b][]=1&a[b][]=2&a[c]=x&d=y")
+// 	q.Nested() // => map[a:map[b:[1 2] c:x] d:y]
+func (q *Query) Nested() (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, p := range q.pairs {
+		path, err := splitBracketKey(p.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err = insertNested(result, path, p.Value); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ParseNestedQuery parses given query string in bracket notation to a nested map, see ParseQuery and Query.Nested for
+// details.
+func ParseNestedQuery(s string) (map[string]interface{}, error) {
+	q, err := ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	return q.Nested()
+}
+
+// insertNested inserts value to container by given path, is the implementation of Query.Nested.
+func insertNested(container map[string]interface{}, path []string, value string) error {
+	key := path[0]
+	existed, ok := container[key]
+	if len(path) == 1 {
+		switch e := existed.(type) {
+		case nil:
+			container[key] = value
+		case string:
+			container[key] = []interface{}{e, value}
+		case []interface{}:
+			container[key] = append(e, value)
+		default:
+			return errQueryTypeConflict
+		}
+		return nil
+	}
+
+	if path[1] == "" { // array
+		var arr []interface{}
+		if ok {
+			if arr, ok = existed.([]interface{}); !ok {
+				return errQueryTypeConflict
+			}
+		}
+		if len(path) == 2 {
+			container[key] = append(arr, value)
+			return nil
+		}
+		var last map[string]interface{}
+		if len(arr) > 0 {
+			if m, ok := arr[len(arr)-1].(map[string]interface{}); ok {
+				if _, exist := m[path[2]]; !exist || path[len(path)-1] == "" {
+					last = m
+				}
+			}
+		}
+		if last == nil {
+			last = make(map[string]interface{})
+			arr = append(arr, last)
+		}
+		container[key] = arr
+		return insertNested(last, path[2:], value)
+	}
+
+	var sub map[string]interface{}
+	if ok {
+		if sub, ok = existed.(map[string]interface{}); !ok {
+			return errQueryTypeConflict
+		}
+	} else {
+		sub = make(map[string]interface{})
+		container[key] = sub
+	}
+	return insertNested(sub, path[1:], value)
+}
+
+// ======
+// struct
+// ======
+
+const (
+	panicNonStructPointer = "xstring: non-nil pointer to struct is required"
+	panicNonStruct        = "xstring: struct or pointer to struct is required"
+)
+
+var (
+	errUnsupportedQueryType = errors.New("xstring: unsupported field type for query")
+)
+
+// queryField represents a parsed struct field with `form` tag.
+type queryField struct {
+	name      string
+	index     int
+	omitempty bool
+}
+
+// parseQueryFields returns the fields of given struct type which can be used in query, fields with `form:"-"` and unexported
+// fields are ignored, and fields without `form` tag use the field name as key. Note that for unexported embedded fields,
+// only the struct (not pointer) ones are kept, because their exported fields can still be accessed.
+func parseQueryFields(typ reflect.Type) []queryField {
+	fields := make([]queryField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" && (!sf.Anonymous || sf.Type.Kind() != reflect.Struct) { // unexported
+			continue
+		}
+		tag := sf.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.IndexByte(tag, ','); idx != -1 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		if name == "" && !sf.Anonymous {
+			name = sf.Name
+		}
+		fields = append(fields, queryField{name: name, index: i, omitempty: strings.Contains(","+opts+",", ",omitempty,")})
+	}
+	return fields
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isStructSliceElem checks whether given slice element type is struct or pointer to struct, which does not implement
+// encoding.TextMarshaler or encoding.TextUnmarshaler. Slices of these structs are not supported in query.
+func isStructSliceElem(elem reflect.Type) bool {
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return false
+	}
+	ptr := reflect.PtrTo(elem)
+	return !elem.Implements(textMarshalerType) && !ptr.Implements(textMarshalerType) && !ptr.Implements(textUnmarshalerType)
+}
+
+// EncodeQueryStruct encodes given struct (or pointer to struct) to Query using `form` tags in field order, and panics if
+// v is not a struct. Here tag `form:"-"` means ignoring the field, `form:",omitempty"` means skipping zero value, slices
+// are encoded as duplicate keys, nested structs are encoded in bracket notation, and anonymous structs without name are
+// flattened. Note that encoding.TextMarshaler is used if the field implements it, and slices of other structs are not
+// supported.
+//
+// Example:
+// 	type Form struct {
+// 		Name  string   `form:"name"`
+// 		Tags  []string `form:"tag"`
+// 		Page  int      `form:"page,omitempty"`
+// 	}
+// 	EncodeQueryStruct(&Form{Name: "a", Tags: []string{"x", "y"}}) // => name=a&tag=x&tag=y
+func EncodeQueryStruct(v interface{}) (*Query, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		panic(panicNonStruct)
+	}
+	q := NewQuery()
+	if err := encodeQueryStruct(q, "", val); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// encodeQueryStruct is the implementation of EncodeQueryStruct.
+func encodeQueryStruct(q *Query, prefix string, val reflect.Value) error {
+	for _, f := range parseQueryFields(val.Type()) {
+		fv := val.Field(f.index)
+		if f.omitempty && isZeroValue(fv) {
+			continue
+		}
+		key := f.name
+		if prefix != "" && key != "" {
+			key = prefix + "[" + key + "]"
+		} else if key == "" {
+			key = prefix
+		}
+		if err := encodeQueryValue(q, key, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeQueryValue encodes a single value to Query with given key.
+func encodeQueryValue(q *Query, key string, fv reflect.Value) error {
+	if fv.Type().Implements(textMarshalerType) && fv.CanInterface() && !(fv.Kind() == reflect.Ptr && fv.IsNil()) {
+		bs, err := fv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		q.Add(key, string(bs))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.Ptr:
+		if fv.IsNil() {
+			return nil
+		}
+		return encodeQueryValue(q, key, fv.Elem())
+	case reflect.Struct:
+		return encodeQueryStruct(q, key, fv)
+	case reflect.Slice, reflect.Array:
+		if isStructSliceElem(fv.Type().Elem()) {
+			return errUnsupportedQueryType
+		}
+		for i := 0; i < fv.Len(); i++ {
+			if err := encodeQueryValue(q, key, fv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	s, ok := formatBasicValue(fv)
+	if !ok {
+		return errUnsupportedQueryType
+	}
+	q.Add(key, s)
+	return nil
+}
+
+// formatBasicValue formats bool, numbers and string to string.
+func formatBasicValue(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true
+	}
+	return "", false
+}
+
+// isZeroValue checks whether given value is the zero value of its type, note that empty slice and map are also regarded
+// as zero value.
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// DecodeQueryStruct decodes Query to given pointer to struct using `form` tags, and panics if v is not a non-nil pointer
+// to struct. Note that the fields whose keys do not exist in Query are kept unchanged, see EncodeQueryStruct for details.
+func DecodeQueryStruct(q *Query, v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		panic(panicNonStructPointer)
+	}
+	return decodeQueryStruct(q, "", val.Elem())
+}
+
+// decodeQueryStruct is the implementation of DecodeQueryStruct.
+func decodeQueryStruct(q *Query, prefix string, val reflect.Value) error {
+	for _, f := range parseQueryFields(val.Type()) {
+		fv := val.Field(f.index)
+		key := f.name
+		if prefix != "" && key != "" {
+			key = prefix + "[" + key + "]"
+		} else if key == "" {
+			key = prefix
+		}
+		if err := decodeQueryValue(q, key, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeQueryValue decodes the values of given key in Query to fv.
+func decodeQueryValue(q *Query, key string, fv reflect.Value) error {
+	if reflect.PtrTo(fv.Type()).Implements(textUnmarshalerType) && fv.Kind() != reflect.Ptr && fv.CanInterface() {
+		if !q.Has(key) {
+			return nil
+		}
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(q.Get(key)))
+	}
+	switch fv.Kind() {
+	case reflect.Ptr:
+		if key == "" && fv.IsNil() { // embedded pointer to struct, whose fields are flattened
+			nv := reflect.New(fv.Type().Elem())
+			if err := decodeQueryValue(q, key, nv.Elem()); err != nil {
+				return err
+			}
+			if !nv.Elem().IsZero() {
+				fv.Set(nv)
+			}
+			return nil
+		}
+		if key != "" && !q.Has(key) && !hasQueryPrefix(q, key+"[") {
+			return nil
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return decodeQueryValue(q, key, fv.Elem())
+	case reflect.Struct:
+		return decodeQueryStruct(q, key, fv)
+	case reflect.Slice:
+		if isStructSliceElem(fv.Type().Elem()) {
+			return errUnsupportedQueryType
+		}
+		if !q.Has(key) {
+			return nil
+		}
+		values := q.GetAll(key)
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, s := range values {
+			if err := parseQueryValue(s, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+	if !q.Has(key) {
+		return nil
+	}
+	return parseQueryValue(q.Get(key), fv)
+}
+
+// hasQueryPrefix checks whether Query has any key with given prefix.
+func hasQueryPrefix(q *Query, prefix string) bool {
+	for _, p := range q.pairs {
+		if strings.HasPrefix(p.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseQueryValue parses string to given bool, numbers, string or encoding.TextUnmarshaler value.
+func parseQueryValue(s string, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return parseQueryValue(s, v.Elem())
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return errUnsupportedQueryType
+	}
+	return nil
+}
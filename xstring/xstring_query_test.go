@@ -0,0 +1,304 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	t.Run("ParseQuery", func(t *testing.T) {
+		for _, tc := range []struct {
+			give      string
+			wantPairs []QueryPair
+			wantErr   bool
+		}{
+			{"", []QueryPair{}, false},
+			{"?", []QueryPair{}, false},
+			{"a=1", []QueryPair{{"a", "1"}}, false},
+			{"?b=2&a=1&b=3&c", []QueryPair{{"b", "2"}, {"a", "1"}, {"b", "3"}, {"c", ""}}, false},
+			{"a=1&&b=", []QueryPair{{"a", "1"}, {"b", ""}}, false},
+			{"a+b=c+d&e%20f=%E4%B8%AD", []QueryPair{{"a b", "c d"}, {"e f", "中"}}, false},
+			{"a=b=c", []QueryPair{{"a", "b=c"}}, false},
+			{"a%5Bb%5D%5B%5D=1", []QueryPair{{"a[b][]", "1"}}, false},
+			{"a=%zz", nil, true},
+			{"%zz=1", nil, true},
+		} {
+			q, err := ParseQuery(tc.give)
+			xtesting.Equal(t, err != nil, tc.wantErr)
+			if !tc.wantErr {
+				xtesting.Equal(t, q.Pairs(), tc.wantPairs)
+				xtesting.Equal(t, q.Len(), len(tc.wantPairs))
+			}
+		}
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		q, _ := ParseQuery("b=2&a=1&b=3&c=")
+		xtesting.Equal(t, q.Keys(), []string{"b", "a", "c"})
+		xtesting.True(t, q.Has("b"))
+		xtesting.True(t, q.Has("c"))
+		xtesting.False(t, q.Has("d"))
+		xtesting.Equal(t, q.Get("b"), "2")
+		xtesting.Equal(t, q.Get("d"), "")
+		xtesting.Equal(t, q.GetAll("b"), []string{"2", "3"})
+		xtesting.Equal(t, q.GetAll("d"), []string{})
+		xtesting.Equal(t, q.Values(), url.Values{"a": {"1"}, "b": {"2", "3"}, "c": {""}})
+
+		q.Add("a", "x y").Add("d", "中")
+		xtesting.Equal(t, q.Encode(), "b=2&a=1&b=3&c=&a=x+y&d=%E4%B8%AD")
+		xtesting.Equal(t, q.EncodeWith(url.PathEscape), "b=2&a=1&b=3&c=&a=x%20y&d=%E4%B8%AD")
+		xtesting.Equal(t, q.String(), "b=2&a=1&b=3&c=&a=x y&d=中")
+		q.Set("b", "4")
+		xtesting.Equal(t, q.String(), "b=4&a=1&c=&a=x y&d=中")
+		q.Set("e", "5")
+		xtesting.Equal(t, q.String(), "b=4&a=1&c=&a=x y&d=中&e=5")
+		q.Del("a").Del("x")
+		xtesting.Equal(t, q.String(), "b=4&c=&d=中&e=5")
+		pairs := q.Pairs()
+		pairs[0].Value = "changed"
+		xtesting.Equal(t, q.Get("b"), "4")
+		xtesting.Equal(t, NewQuery().Encode(), "")
+
+		q = QueryFromValues(url.Values{"b": {"2", "1"}, "a": {"3"}})
+		xtesting.Equal(t, q.String(), "a=3&b=2&b=1")
+	})
+}
+
+type testOrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *testOrderedMap) Keys() []string {
+	return m.keys
+}
+
+func (m *testOrderedMap) Get(key string) (interface{}, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func TestNestedQuery(t *testing.T) {
+	t.Run("AddNested", func(t *testing.T) {
+		q := NewQuery()
+		q.AddNested("a", map[string]interface{}{"c": "x", "b": []int{1, 2}})
+		q.AddNested("d", nil)
+		q.AddNested("e", []byte("bytes"))
+		q.AddNested("f", [2]bool{true, false})
+		q.AddNested("g", []map[string]int{{"h": 1}, {"h": 2}})
+		xtesting.Equal(t, q.String(), "a[b][]=1&a[b][]=2&a[c]=x&d=&e=bytes&f[]=true&f[]=false&g[][h]=1&g[][h]=2")
+
+		i, str := 1, "s"
+		q = NewQuery()
+		q.AddNested("p", &i)
+		q.AddNested("n", (*int)(nil))
+		q.AddNested("m", map[string]*string{"k": &str, "z": nil})
+		q.AddNested("s", []*int{&i, nil})
+		q.AddNested("t", &testQueryInner{X: 2})
+		q.AddNested("b", big.NewInt(3))
+		xtesting.Equal(t, q.String(), "p=1&m[k]=s&s[]=1&t={2 }&b=3")
+
+		om := &testOrderedMap{keys: []string{"z", "y"}, values: map[string]interface{}{
+			"z": 1,
+			"y": &testOrderedMap{keys: []string{"b", "a"}, values: map[string]interface{}{"b": "2", "a": []string{"3", "4"}}},
+		}}
+		q = QueryFromOrderedMap(om)
+		xtesting.Equal(t, q.String(), "z=1&y[b]=2&y[a][]=3&y[a][]=4")
+		xtesting.Equal(t, q.Encode(), "z=1&y%5Bb%5D=2&y%5Ba%5D%5B%5D=3&y%5Ba%5D%5B%5D=4")
+	})
+
+	t.Run("ParseNestedQuery", func(t *testing.T) {
+		for _, tc := range []struct {
+			give    string
+			want    map[string]interface{}
+			wantErr error
+		}{
+			{"", map[string]interface{}{}, nil},
+			{"a=1&b=2", map[string]interface{}{"a": "1", "b": "2"}, nil},
+			{"a=1&a=2&a=3", map[string]interface{}{"a": []interface{}{"1", "2", "3"}}, nil},
+			{"a[]=1&a[]=2", map[string]interface{}{"a": []interface{}{"1", "2"}}, nil},
+			{"a[b]=1&a[c]=2", map[string]interface{}{"a": map[string]interface{}{"b": "1", "c": "2"}}, nil},
+			{"a[b][]=1&a[b][]=2&a[c]=x&d=y", map[string]interface{}{
+				"a": map[string]interface{}{"b": []interface{}{"1", "2"}, "c": "x"}, "d": "y"}, nil},
+			{"a[b][c][d]=1", map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": map[string]interface{}{"d": "1"}}}}, nil},
+			{"u[][n]=a&u[][a]=1&u[][n]=b&u[][a]=2", map[string]interface{}{"u": []interface{}{
+				map[string]interface{}{"n": "a", "a": "1"}, map[string]interface{}{"n": "b", "a": "2"}}}, nil},
+			{"a[][b][]=1&a[][b][]=2", map[string]interface{}{"a": []interface{}{map[string]interface{}{"b": []interface{}{"1", "2"}}}}, nil},
+			{"a%5Bb%5D=1", map[string]interface{}{"a": map[string]interface{}{"b": "1"}}, nil},
+			{"a=1&a[b]=2", nil, errQueryTypeConflict},
+			{"a[b]=1&a=2", nil, errQueryTypeConflict},
+			{"a=1&a[]=2", nil, errQueryTypeConflict},
+			{"a[]=1&a[b]=2", nil, errQueryTypeConflict},
+			{"a[b]=1&a[]=2", nil, errQueryTypeConflict},
+			{"[a]=1", nil, errInvalidQueryBracket},
+			{"a[b=1", nil, errInvalidQueryBracket},
+			{"a[b]c=1", nil, errInvalidQueryBracket},
+			{"a=%zz", nil, errInvalidQueryEscape},
+		} {
+			m, err := ParseNestedQuery(tc.give)
+			xtesting.Equal(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				xtesting.Equal(t, m, tc.want)
+			}
+		}
+	})
+}
+
+type testQueryInner struct {
+	X int    `form:"x"`
+	Y string `form:"y,omitempty"`
+}
+
+type TestQueryEmbedded struct {
+	E string `form:"e"`
+}
+
+type testQueryForm struct {
+	TestQueryEmbedded
+	Name     string          `form:"name"`
+	Tags     []string        `form:"tag"`
+	Page     int             `form:"page,omitempty"`
+	Ratio    float64         `form:"ratio"`
+	OK       bool            `form:"ok"`
+	Uint     uint8           `form:"u"`
+	Ptr      *int            `form:"ptr"`
+	Inner    testQueryInner  `form:"inner"`
+	InnerPtr *testQueryInner `form:"inner_ptr"`
+	Time     time.Time       `form:"time"`
+	Ignored  string          `form:"-"`
+	NoTag    string
+	private  string
+}
+
+type testQueryUnexported struct {
+	U string `form:"u"`
+}
+
+type testQueryString string
+
+type testQueryUnexportedForm struct {
+	testQueryUnexported `form:",omitempty"`
+	*testQueryInner
+	testQueryString
+	Name string `form:"name"`
+}
+
+func TestQueryStructWithUnexportedEmbedded(t *testing.T) {
+	form := &testQueryUnexportedForm{testQueryUnexported{U: "x"}, &testQueryInner{X: 1}, "s", "n"}
+	q, err := EncodeQueryStruct(form)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, q.String(), "u=x&name=n")
+	form.testQueryUnexported.U = ""
+	q, err = EncodeQueryStruct(form)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, q.String(), "name=n")
+
+	q, _ = ParseQuery("u=y&x=2&name=m")
+	decoded := &testQueryUnexportedForm{}
+	xtesting.Nil(t, DecodeQueryStruct(q, decoded))
+	xtesting.Equal(t, decoded, &testQueryUnexportedForm{testQueryUnexported: testQueryUnexported{U: "y"}, Name: "m"})
+}
+
+type TestQueryEmbeddedPtr struct {
+	A string `form:"a"`
+}
+
+type testQueryPtrForm struct {
+	*TestQueryEmbeddedPtr
+	B string `form:"b"`
+}
+
+func TestQueryStructWithEmbeddedPointer(t *testing.T) {
+	form := &testQueryPtrForm{&TestQueryEmbeddedPtr{A: "x"}, "y"}
+	q, err := EncodeQueryStruct(form)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, q.String(), "a=x&b=y")
+	decoded := &testQueryPtrForm{}
+	xtesting.Nil(t, DecodeQueryStruct(q, decoded))
+	xtesting.Equal(t, decoded, form)
+
+	q, _ = ParseQuery("b=z")
+	decoded = &testQueryPtrForm{}
+	xtesting.Nil(t, DecodeQueryStruct(q, decoded))
+	xtesting.Equal(t, decoded, &testQueryPtrForm{B: "z"})
+	q, _ = ParseQuery("a=w")
+	decoded = &testQueryPtrForm{TestQueryEmbeddedPtr: &TestQueryEmbeddedPtr{A: "v"}}
+	xtesting.Nil(t, DecodeQueryStruct(q, decoded))
+	xtesting.Equal(t, decoded.A, "w")
+}
+
+func TestQueryStruct(t *testing.T) {
+	ten := 10
+	form := &testQueryForm{
+		TestQueryEmbedded: TestQueryEmbedded{E: "emb"},
+		Name:              "a b",
+		Tags:              []string{"x", "y"},
+		Ratio:             0.5,
+		OK:                true,
+		Uint:              255,
+		Ptr:               &ten,
+		Inner:             testQueryInner{X: 1},
+		Time:              time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Ignored:           "ignored",
+		NoTag:             "no",
+		private:           "private",
+	}
+	q, err := EncodeQueryStruct(form)
+	xtesting.Nil(t, err)
+	want := "e=emb&name=a b&tag=x&tag=y&ratio=0.5&ok=true&u=255&ptr=10&inner[x]=1&time=2021-01-02T03:04:05Z&NoTag=no"
+	xtesting.Equal(t, q.String(), want)
+	q2, err := EncodeQueryStruct(*form)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, q2.String(), want)
+
+	form.InnerPtr = &testQueryInner{X: 2, Y: "z"}
+	form.Page = 3
+	q, _ = EncodeQueryStruct(form)
+	xtesting.Equal(t, q.String(), "e=emb&name=a b&tag=x&tag=y&page=3&ratio=0.5&ok=true&u=255&ptr=10&inner[x]=1&inner_ptr[x]=2&inner_ptr[y]=z&time=2021-01-02T03:04:05Z&NoTag=no")
+
+	decoded := &testQueryForm{Ignored: "keep", Name: "old"}
+	xtesting.Nil(t, DecodeQueryStruct(q, decoded))
+	form.Ignored, form.private = "keep", ""
+	xtesting.Equal(t, decoded, form)
+
+	// partial
+	q, _ = ParseQuery("name=new&tag=1&page=x")
+	decoded = &testQueryForm{Name: "old", OK: true}
+	xtesting.NotNil(t, DecodeQueryStruct(q, decoded))
+	q, _ = ParseQuery("name=new&tag=1")
+	decoded = &testQueryForm{Name: "old", OK: true}
+	xtesting.Nil(t, DecodeQueryStruct(q, decoded))
+	xtesting.Equal(t, decoded, &testQueryForm{Name: "new", OK: true, Tags: []string{"1"}})
+
+	for _, give := range []string{"ok=x", "u=256", "ratio=x", "ptr=x", "inner[x]=x", "time=x", "inner_ptr[x]=x"} {
+		q, _ = ParseQuery(give)
+		xtesting.NotNil(t, DecodeQueryStruct(q, &testQueryForm{}))
+	}
+
+	// unsupported
+	_, err = EncodeQueryStruct(&struct{ M map[string]int }{M: map[string]int{"a": 1}})
+	xtesting.Equal(t, err, errUnsupportedQueryType)
+	q, _ = ParseQuery("M=1")
+	xtesting.Equal(t, DecodeQueryStruct(q, &struct{ M map[string]int }{}), errUnsupportedQueryType)
+
+	// slice of struct
+	_, err = EncodeQueryStruct(&struct{ Items []testQueryInner }{Items: []testQueryInner{{X: 1}}})
+	xtesting.Equal(t, err, errUnsupportedQueryType)
+	q, _ = ParseQuery("Items[x]=1")
+	xtesting.Equal(t, DecodeQueryStruct(q, &struct{ Items []*testQueryInner }{}), errUnsupportedQueryType)
+	times := &struct{ Times []time.Time }{Times: []time.Time{time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)}}
+	q, err = EncodeQueryStruct(times)
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, q.String(), "Times=2021-01-02T03:04:05Z")
+	decodedTimes := &struct{ Times []time.Time }{}
+	xtesting.Nil(t, DecodeQueryStruct(q, decodedTimes))
+	xtesting.Equal(t, decodedTimes, times)
+
+	xtesting.PanicWithValue(t, panicNonStruct, func() { _, _ = EncodeQueryStruct(1) })
+	xtesting.PanicWithValue(t, panicNonStruct, func() { _, _ = EncodeQueryStruct((*testQueryForm)(nil)) })
+	xtesting.PanicWithValue(t, panicNonStructPointer, func() { _ = DecodeQueryStruct(q, testQueryForm{}) })
+	xtesting.PanicWithValue(t, panicNonStructPointer, func() { _ = DecodeQueryStruct(q, (*testQueryForm)(nil)) })
+}
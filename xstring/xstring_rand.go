@@ -0,0 +1,228 @@
+package xstring
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	panicEmptyRunes      = "xstring: empty runes"
+	panicNonPositiveN    = "xstring: non-positive n"
+	panicNilRandSource   = "xstring: nil rand source"
+	panicCryptoRandError = "xstring: failed to read from crypto/rand: %v"
+)
+
+// RandSource represents a source of uniformly distributed random numbers, which is used to generate random strings. Note
+// that the implementation must be safe for concurrent use.
+type RandSource interface {
+	// Uint64 returns a uniformly distributed random uint64.
+	Uint64() uint64
+}
+
+// cryptoRandSource is a RandSource using crypto/rand, see NewCryptoRandSource.
+type cryptoRandSource struct{}
+
+// NewCryptoRandSource creates a RandSource which reads from crypto/rand, this source is suitable for generating secrets,
+// such as tokens and passwords. Note that Uint64 panics if failed to read from crypto/rand, which hardly happens.
+func NewCryptoRandSource() RandSource {
+	return cryptoRandSource{}
+}
+
+// Uint64 implements RandSource.
+func (cryptoRandSource) Uint64() uint64 {
+	buf := [8]byte{}
+	if _, err := io.ReadFull(rand.Reader, buf[:]); err != nil {
+		panic(fmt.Sprintf(panicCryptoRandError, err))
+	}
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// seededRandSource is a RandSource using math/rand with a lock, see NewSeededRandSource.
+type seededRandSource struct {
+	mu sync.Mutex
+	r  *mathrand.Rand
+}
+
+// NewSeededRandSource creates a deterministic RandSource using math/rand with given seed, that is the same seed always
+// produces the same sequence, this source is suitable for tests, but must not be used to generate secrets.
+func NewSeededRandSource(seed int64) RandSource {
+	return &seededRandSource{r: mathrand.New(mathrand.NewSource(seed))}
+}
+
+// Uint64 implements RandSource.
+func (s *seededRandSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Uint64()
+}
+
+// _defaultRandSource is the default RandSource used by RandString, which is seeded only once.
+var _defaultRandSource = NewSeededRandSource(time.Now().UnixNano())
+
+// RandIntn returns a uniformly distributed random int in [0, n) from given RandSource, it uses rejection sampling so the
+// result is unbiased, and panics if n is not positive.
+func RandIntn(src RandSource, n int) int {
+	if src == nil {
+		panic(panicNilRandSource)
+	}
+	if n <= 0 {
+		panic(panicNonPositiveN)
+	}
+	un := uint64(n)
+	if un&(un-1) == 0 { // power of 2
+		return int(src.Uint64() & (un - 1))
+	}
+	threshold := -un % un // that is 2^64 % n, values less than it are rejected
+	for {
+		v := src.Uint64()
+		if v >= threshold {
+			return int(v % un)
+		}
+	}
+}
+
+// RandString generates a string by given rune slice in random order, using the default math/rand based RandSource which
+// is seeded once. Note that this function is not suitable for generating secrets, please use RandStringWith with the source
+// from NewCryptoRandSource instead.
+func RandString(count int, runes []rune) string {
+	return RandStringWith(_defaultRandSource, count, runes)
+}
+
+// RandStringWith generates a string by given rune slice in random order, using given RandSource. Note that each rune is
+// selected in an unbiased way, and this function panics if runes is empty when count is positive.
+func RandStringWith(src RandSource, count int, runes []rune) string {
+	if count <= 0 {
+		return ""
+	}
+	if len(runes) == 0 {
+		panic(panicEmptyRunes)
+	}
+	b := make([]rune, count)
+	for i := range b {
+		b[i] = runes[RandIntn(src, len(runes))]
+	}
+	return string(b)
+}
+
+var (
+	capitalLetterRunes         = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	lowercaseLetterRunes       = []rune("abcdefghijklmnopqrstuvwxyz")
+	allcaseLetterRunes         = append(capitalLetterRunes, lowercaseLetterRunes...)
+	numberRunes                = []rune("0123456789")
+	capitalLetterNumberRunes   = append(capitalLetterRunes, numberRunes...)
+	lowercaseLetterNumberRunes = append(lowercaseLetterRunes, numberRunes...)
+	symbolRunes                = []rune("!#$%&()*+,-./:;<=>?@[]^_{|}~")
+)
+
+// RandCapitalLetterString generates a random string combined by capital letters, that is ABCDEFGHIJKLMNOPQRSTUVWXYZ.
+func RandCapitalLetterString(count int) string {
+	return RandString(count, capitalLetterRunes)
+}
+
+// RandLowercaseLetterString generates a random string combined by lowercase letters, that is abcdefghijklmnopqrstuvwxyz.
+func RandLowercaseLetterString(count int) string {
+	return RandString(count, lowercaseLetterRunes)
+}
+
+// RandLetterString generates a random string combined by allcase letters, that is ABCDEFGHIJKLMNOPQRSTUVWXYZ + abcdefghijklmnopqrstuvwxyz.
+func RandLetterString(count int) string {
+	return RandString(count, allcaseLetterRunes)
+}
+
+// RandNumberString generates a random string combined by numbers, that is 0123456789.
+func RandNumberString(count int) string {
+	return RandString(count, numberRunes)
+}
+
+// RandCapitalLetterNumberString generates a random string combined by capital letters and numbers, that is ABCDEFGHIJKLMNOPQRSTUVWXYZ + 0123456789.
+func RandCapitalLetterNumberString(count int) string {
+	return RandString(count, capitalLetterNumberRunes)
+}
+
+// RandLowercaseLetterNumberString generates a random string combined by lowercase letters and numbers, that is abcdefghijklmnopqrstuvwxyz + 0123456789.
+func RandLowercaseLetterNumberString(count int) string {
+	return RandString(count, lowercaseLetterNumberRunes)
+}
+
+// PasswordClass represents a character class used in RandPassword, with the minimum number of characters required.
+type PasswordClass struct {
+	// Runes represents the characters of this class.
+	Runes []rune
+
+	// Min represents the minimum number of characters from this class in password.
+	Min int
+}
+
+var (
+	// PasswordCapitalLetters represents the capital letter class which requires at least one character.
+	PasswordCapitalLetters = PasswordClass{Runes: capitalLetterRunes, Min: 1}
+
+	// PasswordLowercaseLetters represents the lowercase letter class which requires at least one character.
+	PasswordLowercaseLetters = PasswordClass{Runes: lowercaseLetterRunes, Min: 1}
+
+	// PasswordNumbers represents the number class which requires at least one character.
+	PasswordNumbers = PasswordClass{Runes: numberRunes, Min: 1}
+
+	// PasswordSymbols represents the symbol class which requires at least one character, that is !#$%&()*+,-./:;<=>?@[]^_{|}~.
+	PasswordSymbols = PasswordClass{Runes: symbolRunes, Min: 1}
+)
+
+var (
+	errPasswordNoClass   = errors.New("xstring: no character class for password")
+	errPasswordEmptyRune = errors.New("xstring: empty runes in password character class")
+	errPasswordTooShort  = errors.New("xstring: password length is less than the sum of minimum requirements")
+)
+
+// RandPassword generates a random password with given length using given RandSource and character classes. The password
+// contains at least Min characters of each class, and the remaining characters are selected from all the classes, then
+// all characters are shuffled. An error will be returned if the classes are invalid or length is not enough.
+//
+// Example:
+// 	RandPassword(NewCryptoRandSource(), 16, PasswordCapitalLetters, PasswordLowercaseLetters, PasswordNumbers) // => 3Lw0hTqZc9bXmRkA
+// 	RandPassword(NewCryptoRandSource(), 8, PasswordClass{Runes: []rune("abc"), Min: 8}) // => cabbacab
+func RandPassword(src RandSource, length int, classes ...PasswordClass) (string, error) {
+	if len(classes) == 0 {
+		return "", errPasswordNoClass
+	}
+	all := make([]rune, 0)
+	required := 0
+	for _, class := range classes {
+		if len(class.Runes) == 0 {
+			return "", errPasswordEmptyRune
+		}
+		if class.Min > 0 {
+			required += class.Min
+		}
+		all = append(all, class.Runes...)
+	}
+	if length < required {
+		return "", errPasswordTooShort
+	}
+
+	b := make([]rune, 0, length)
+	for _, class := range classes {
+		for i := 0; i < class.Min; i++ {
+			b = append(b, class.Runes[RandIntn(src, len(class.Runes))])
+		}
+	}
+	for len(b) < length {
+		b = append(b, all[RandIntn(src, len(all))])
+	}
+	for i := len(b) - 1; i > 0; i-- { // Fisher-Yates shuffle
+		j := RandIntn(src, i+1)
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b), nil
+}
+
+// RandStrongPassword generates a random password with given length using the crypto/rand based RandSource, the password
+// contains at least one capital letter, lowercase letter, number and symbol, so length must be at least 4.
+func RandStrongPassword(length int) (string, error) {
+	return RandPassword(NewCryptoRandSource(), length, PasswordCapitalLetters, PasswordLowercaseLetters, PasswordNumbers, PasswordSymbols)
+}
@@ -0,0 +1,165 @@
+package xstring
+
+import (
+	"fmt"
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+func TestRandXXXString(t *testing.T) {
+	for _, tc := range []struct {
+		giveFn    func(int) string
+		giveCount int
+	}{
+		{RandCapitalLetterString, 0},
+		{RandCapitalLetterString, 5},
+		{RandCapitalLetterString, 20},
+
+		{RandLowercaseLetterString, 0},
+		{RandLowercaseLetterString, 5},
+		{RandLowercaseLetterString, 20},
+
+		{RandLetterString, 0},
+		{RandLetterString, 5},
+		{RandLetterString, 20},
+
+		{RandNumberString, 0},
+		{RandNumberString, 5},
+		{RandNumberString, 20},
+
+		{RandCapitalLetterNumberString, 0},
+		{RandCapitalLetterNumberString, 5},
+		{RandCapitalLetterNumberString, 20},
+
+		{RandLowercaseLetterNumberString, 0},
+		{RandLowercaseLetterNumberString, 5},
+		{RandLowercaseLetterNumberString, 20},
+	} {
+		r := tc.giveFn(tc.giveCount)
+		if tc.giveCount == 0 {
+			xtesting.Equal(t, r, "")
+		} else {
+			xtesting.Equal(t, len(r), tc.giveCount)
+
+			for i := 0; i < 4; i++ {
+				r1 := tc.giveFn(tc.giveCount)
+				r2 := tc.giveFn(tc.giveCount)
+				xtesting.NotEqual(t, r1, r2)
+				if showFn() {
+					fmt.Println(r1, r2)
+				}
+			}
+		}
+	}
+}
+
+func TestRandSource(t *testing.T) {
+	t.Run("seeded", func(t *testing.T) {
+		s1, s2 := NewSeededRandSource(42), NewSeededRandSource(42)
+		for i := 0; i < 100; i++ {
+			xtesting.Equal(t, s1.Uint64(), s2.Uint64())
+		}
+		xtesting.Equal(t, RandStringWith(NewSeededRandSource(7), 32, allcaseLetterRunes), RandStringWith(NewSeededRandSource(7), 32, allcaseLetterRunes))
+		xtesting.NotEqual(t, RandStringWith(NewSeededRandSource(7), 32, allcaseLetterRunes), RandStringWith(NewSeededRandSource(8), 32, allcaseLetterRunes))
+	})
+
+	t.Run("crypto", func(t *testing.T) {
+		src := NewCryptoRandSource()
+		set := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			s := RandStringWith(src, 16, lowercaseLetterNumberRunes)
+			xtesting.Equal(t, len(s), 16)
+			xtesting.False(t, set[s])
+			set[s] = true
+		}
+	})
+
+	t.Run("tight loop", func(t *testing.T) {
+		set := make(map[string]bool)
+		mu := sync.Mutex{}
+		wg := sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					s := RandLetterString(20)
+					mu.Lock()
+					set[s] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		xtesting.Equal(t, len(set), 8*200)
+	})
+
+	t.Run("RandIntn", func(t *testing.T) {
+		src := NewSeededRandSource(1)
+		counts := make([]int, 3)
+		for i := 0; i < 30000; i++ {
+			n := RandIntn(src, 3)
+			xtesting.True(t, n >= 0 && n < 3)
+			counts[n]++
+		}
+		for _, c := range counts {
+			xtesting.InDelta(t, c, 10000, 500)
+		}
+		for i := 0; i < 100; i++ {
+			xtesting.Equal(t, RandIntn(src, 1), 0)
+			n := RandIntn(src, 8)
+			xtesting.True(t, n >= 0 && n < 8)
+		}
+
+		xtesting.PanicWithValue(t, panicNilRandSource, func() { RandIntn(nil, 1) })
+		xtesting.PanicWithValue(t, panicNonPositiveN, func() { RandIntn(src, 0) })
+		xtesting.PanicWithValue(t, panicNonPositiveN, func() { RandIntn(src, -1) })
+		xtesting.PanicWithValue(t, panicEmptyRunes, func() { RandStringWith(src, 1, nil) })
+		xtesting.NotPanic(t, func() { RandStringWith(src, 0, nil) })
+	})
+}
+
+func TestRandPassword(t *testing.T) {
+	src := NewCryptoRandSource()
+	for i := 0; i < 200; i++ {
+		p, err := RandStrongPassword(4 + i%20)
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, len(p), 4+i%20)
+		xtesting.True(t, strings.IndexFunc(p, unicode.IsUpper) != -1)
+		xtesting.True(t, strings.IndexFunc(p, unicode.IsLower) != -1)
+		xtesting.True(t, strings.IndexFunc(p, unicode.IsDigit) != -1)
+		xtesting.True(t, strings.ContainsAny(p, string(symbolRunes)))
+	}
+
+	p, err := RandPassword(src, 10, PasswordClass{Runes: []rune("a"), Min: 3}, PasswordClass{Runes: []rune("b"), Min: 7})
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, strings.Count(p, "a"), 3)
+	xtesting.Equal(t, strings.Count(p, "b"), 7)
+	p, err = RandPassword(src, 6, PasswordClass{Runes: []rune("x")}, PasswordClass{Runes: []rune("あ"), Min: 2})
+	xtesting.Nil(t, err)
+	xtesting.Equal(t, len([]rune(p)), 6)
+	xtesting.True(t, strings.Count(p, "あ") >= 2)
+	p1, _ := RandPassword(NewSeededRandSource(3), 12, PasswordCapitalLetters, PasswordNumbers)
+	p2, _ := RandPassword(NewSeededRandSource(3), 12, PasswordCapitalLetters, PasswordNumbers)
+	xtesting.Equal(t, p1, p2)
+
+	for _, tc := range []struct {
+		giveLength  int
+		giveClasses []PasswordClass
+		wantErr     error
+	}{
+		{8, nil, errPasswordNoClass},
+		{8, []PasswordClass{{Runes: nil, Min: 1}}, errPasswordEmptyRune},
+		{3, []PasswordClass{PasswordCapitalLetters, PasswordLowercaseLetters, PasswordNumbers, PasswordSymbols}, errPasswordTooShort},
+		{5, []PasswordClass{{Runes: []rune("a"), Min: 6}}, errPasswordTooShort},
+		{0, []PasswordClass{{Runes: []rune("a")}}, nil},
+	} {
+		_, err := RandPassword(src, tc.giveLength, tc.giveClasses...)
+		xtesting.Equal(t, err, tc.wantErr)
+	}
+	_, err = RandStrongPassword(3)
+	xtesting.Equal(t, err, errPasswordTooShort)
+}
@@ -0,0 +1,269 @@
+package xstring
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+var (
+	errShellUnclosedQuote     = errors.New("xstring: unclosed quote in shell string")
+	errShellUnclosedEscape    = errors.New("xstring: unexpected backslash at the end of shell string")
+	errShellBadSubstitution   = errors.New("xstring: bad variable substitution in shell string")
+	errShellEmptySubstitution = errors.New("xstring: empty variable name in shell string")
+)
+
+// ShellSplit splits given string to words using POSIX shell quoting rules, that is: words are separated by unquoted blanks,
+// characters in single quotes are kept literally, only $ ` " \ and newline can be escaped by backslash in double quotes, a
+// backslash-newline pair is a line continuation, and `#` at the beginning of a word starts a comment until the end of line.
+// Note that variables are not expanded by this function, please use ShellSplitExpand if needed.
+//
+// Example:
+// 	ShellSplit(`cp -r "my dir" 'it''s' a\ b # comment`) // => ["cp", "-r", "my dir", "its", "a b"]
+// 	ShellSplit(`echo "unclosed`)                       // => error
+func ShellSplit(s string) ([]string, error) {
+	return shellSplit(s, nil)
+}
+
+// ShellSplitExpand splits given string to words like ShellSplit, and expands variables in unquoted and double-quoted text
+// using given lookup function, see ShellExpand for the supported syntax. Note that the expanded values are not split to
+// multiple words again, and an unquoted word which is expanded to empty is removed. If lookup is nil, os.LookupEnv will be
+// used.
+//
+// Example:
+// 	ShellSplitExpand(`ls $HOME "${DIR:-/tmp}" '$HOME'`, lookup) // => ["ls", "/home/user", "/tmp", "$HOME"]
+func ShellSplitExpand(s string, lookup func(name string) (string, bool)) ([]string, error) {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	return shellSplit(s, lookup)
+}
+
+// shellSplit is the implementation of ShellSplit and ShellSplitExpand, variables are not expanded if lookup is nil.
+func shellSplit(s string, lookup func(name string) (string, bool)) ([]string, error) {
+	words := make([]string, 0)
+	sb := strings.Builder{}
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inWord {
+				words = append(words, sb.String())
+				sb.Reset()
+				inWord = false
+			}
+		case c == '#' && !inWord:
+			for i+1 < len(s) && s[i+1] != '\n' {
+				i++
+			}
+		case c == '\\':
+			if i+1 >= len(s) {
+				return nil, errShellUnclosedEscape
+			}
+			i++
+			if s[i] != '\n' { // line continuation
+				sb.WriteByte(s[i])
+				inWord = true
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end == -1 {
+				return nil, errShellUnclosedQuote
+			}
+			sb.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			closed := false
+			for i++; i < len(s); i++ {
+				c = s[i]
+				if c == '"' {
+					closed = true
+					break
+				}
+				if c == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) != -1 {
+					i++
+					if s[i] != '\n' {
+						sb.WriteByte(s[i])
+					}
+					continue
+				}
+				if c == '$' && lookup != nil {
+					value, next, err := expandShellVariable(s, i, lookup)
+					if err != nil {
+						return nil, err
+					}
+					sb.WriteString(value)
+					i = next - 1
+					continue
+				}
+				sb.WriteByte(c)
+			}
+			if !closed {
+				return nil, errShellUnclosedQuote
+			}
+			inWord = true
+		case c == '$' && lookup != nil:
+			value, next, err := expandShellVariable(s, i, lookup)
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(value)
+			i = next - 1
+			if value != "" {
+				inWord = true
+			}
+		default:
+			sb.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, sb.String())
+	}
+	return words, nil
+}
+
+// ShellExpand expands variables in given string using given lookup function, quotes and backslashes are not treated
+// specially. Supported syntax are $NAME, ${NAME}, ${NAME-word} and ${NAME:-word} (use word if NAME is unset, or unset and
+// empty for `:-`), ${NAME+word} and ${NAME:+word} (use word if NAME is set, or set and non-empty for `:+`), here word can
+// also contain variables. Note that undefined variables are expanded to empty, and a `$` which is not followed by a name is
+// kept. If lookup is nil, os.LookupEnv will be used.
+//
+// Example:
+// 	lookup := func(name string) (string, bool) { return map[string]string{"USER": "alice"}[name], name == "USER" }
+// 	ShellExpand("hello $USER, ${GREETING:-hi ${USER}}, $5", lookup) // => hello alice, hi alice, $5
+func ShellExpand(s string, lookup func(name string) (string, bool)) (string, error) {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	sb := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		value, next, err := expandShellVariable(s, i, lookup)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(value)
+		i = next - 1
+	}
+	return sb.String(), nil
+}
+
+// shellVariableNameLength returns the length of the leading variable name in s, that is [A-Za-z_][A-Za-z0-9_]*.
+func shellVariableNameLength(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		return i
+	}
+	return len(s)
+}
+
+// expandShellVariable expands the variable started at s[i] (which is `$`), and returns the expanded value and the index
+// after the variable.
+func expandShellVariable(s string, i int, lookup func(name string) (string, bool)) (string, int, error) {
+	if i+1 >= len(s) || s[i+1] != '{' {
+		n := shellVariableNameLength(s[i+1:])
+		if n == 0 {
+			return "$", i + 1, nil
+		}
+		value, _ := lookup(s[i+1 : i+1+n])
+		return value, i + 1 + n, nil
+	}
+
+	// ${...}
+	depth, end := 0, -1
+	for j := i + 1; j < len(s) && end == -1; j++ {
+		switch s[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				end = j
+			}
+		}
+	}
+	if end == -1 {
+		return "", 0, errShellBadSubstitution
+	}
+	content := s[i+2 : end]
+	n := shellVariableNameLength(content)
+	if n == 0 {
+		return "", 0, errShellEmptySubstitution
+	}
+	name, op := content[:n], content[n:]
+	value, ok := lookup(name)
+	if op == "" {
+		return value, end + 1, nil
+	}
+
+	colon := strings.HasPrefix(op, ":")
+	if colon {
+		op = op[1:]
+	}
+	if op == "" || (op[0] != '-' && op[0] != '+') {
+		return "", 0, errShellBadSubstitution
+	}
+	set := ok && (!colon || value != "")
+	if (op[0] == '-' && set) || (op[0] == '+' && !set) {
+		if op[0] == '+' {
+			value = ""
+		}
+		return value, end + 1, nil
+	}
+	word, err := ShellExpand(op[1:], lookup)
+	if err != nil {
+		return "", 0, err
+	}
+	return word, end + 1, nil
+}
+
+// isShellSafe checks whether given string can be used in shell without quoting.
+func isShellSafe(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || strings.IndexByte("@%+=:,./_-", c) != -1 {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// ShellQuote quotes given string so that it can be used safely as a single word in POSIX shell. The string is returned
+// directly if it only contains safe characters, otherwise it is wrapped in single quotes.
+//
+// Example:
+// 	ShellQuote("file.txt")   // => file.txt
+// 	ShellQuote("")           // => ''
+// 	ShellQuote("my file")    // => 'my file'
+// 	ShellQuote("it's $HOME") // => 'it'"'"'s $HOME'
+func ShellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if isShellSafe(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// ShellJoin quotes each word by ShellQuote and joins them with space, this is the reverse of ShellSplit.
+//
+// Example:
+// 	ShellJoin([]string{"echo", "hello world", ""}) // => echo 'hello world' ''
+func ShellJoin(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		quoted = append(quoted, ShellQuote(w))
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,146 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"os"
+	"testing"
+)
+
+func TestShellSplit(t *testing.T) {
+	for _, tc := range []struct {
+		give    string
+		want    []string
+		wantErr error
+	}{
+		{"", []string{}, nil},
+		{"   \t\n ", []string{}, nil},
+		{"a b  c", []string{"a", "b", "c"}, nil},
+		{`cp -r "my dir" 'it''s' a\ b # comment`, []string{"cp", "-r", "my dir", "its", "a b"}, nil},
+		{`a#b #c d` + "\n" + `e`, []string{"a#b", "e"}, nil},
+		{"# only comment", []string{}, nil},
+		{`'' "" x""y`, []string{"", "", "xy"}, nil},
+		{`'a\b "c"'`, []string{`a\b "c"`}, nil},
+		{`"a\b \$ \" \\ \` + "`" + `"`, []string{`a\b $ " \ ` + "`"}, nil},
+		{"a\\\nb \"c\\\nd\"", []string{"ab", "cd"}, nil},
+		{"\\\n a", []string{"a"}, nil},
+		{`\'\"\#`, []string{`'"#`}, nil},
+		{`$HOME "${X:-y}"`, []string{"$HOME", "${X:-y}"}, nil},
+		{`中文 "テ キ"`, []string{"中文", "テ キ"}, nil},
+		{`"unclosed`, nil, errShellUnclosedQuote},
+		{`'unclosed`, nil, errShellUnclosedQuote},
+		{`a\`, nil, errShellUnclosedEscape},
+	} {
+		words, err := ShellSplit(tc.give)
+		xtesting.Equal(t, err, tc.wantErr)
+		xtesting.Equal(t, words, tc.want)
+	}
+}
+
+func TestShellExpand(t *testing.T) {
+	env := map[string]string{"USER": "alice", "HOME": "/home/alice", "EMPTY": "", "A_1": "a1"}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	t.Run("ShellExpand", func(t *testing.T) {
+		for _, tc := range []struct {
+			give    string
+			want    string
+			wantErr error
+		}{
+			{"", "", nil},
+			{"plain", "plain", nil},
+			{"$USER/${HOME}/$A_1/$A_1x/$UNSET.", "alice//home/alice/a1//.", nil},
+			{"$ $5 $$ $", "$ $5 $$ $", nil},
+			{"'$USER' \"$USER\"", "'alice' \"alice\"", nil},
+			{"${UNSET-d}|${EMPTY-d}|${USER-d}", "d||alice", nil},
+			{"${UNSET:-d}|${EMPTY:-d}|${USER:-d}", "d|d|alice", nil},
+			{"${UNSET+a}|${EMPTY+a}|${USER+a}", "|a|a", nil},
+			{"${UNSET:+a}|${EMPTY:+a}|${USER:+a}", "||a", nil},
+			{"${UNSET:-hi ${USER:-x}}", "hi alice", nil},
+			{"${UNSET:-}", "", nil},
+			{"${USER", "", errShellBadSubstitution},
+			{"${USER:x}", "", errShellBadSubstitution},
+			{"${USER:}", "", errShellBadSubstitution},
+			{"${}", "", errShellEmptySubstitution},
+			{"${1}", "", errShellEmptySubstitution},
+			{"${UNSET:-${}}", "", errShellEmptySubstitution},
+		} {
+			s, err := ShellExpand(tc.give, lookup)
+			xtesting.Equal(t, err, tc.wantErr)
+			xtesting.Equal(t, s, tc.want)
+		}
+	})
+
+	t.Run("ShellSplitExpand", func(t *testing.T) {
+		for _, tc := range []struct {
+			give    string
+			want    []string
+			wantErr error
+		}{
+			{`ls $HOME "${DIR:-/tmp}" '$HOME'`, []string{"ls", "/home/alice", "/tmp", "$HOME"}, nil},
+			{`echo $UNSET x "$UNSET" $EMPTY`, []string{"echo", "x", ""}, nil},
+			{`a$USER"-$USER"\$USER`, []string{"aalice-alice$USER"}, nil},
+			{`"${X:-a b}" ${X:-a b}`, []string{"a b", "a b"}, nil},
+			{`"\${USER}"`, []string{"${USER}"}, nil},
+			{`${USER`, nil, errShellBadSubstitution},
+			{`"${}"`, nil, errShellEmptySubstitution},
+		} {
+			words, err := ShellSplitExpand(tc.give, lookup)
+			xtesting.Equal(t, err, tc.wantErr)
+			xtesting.Equal(t, words, tc.want)
+		}
+	})
+
+	t.Run("os.LookupEnv", func(t *testing.T) {
+		xtesting.Nil(t, os.Setenv("XSTRING_SHELL_TEST", "ok"))
+		defer os.Unsetenv("XSTRING_SHELL_TEST")
+		s, err := ShellExpand("$XSTRING_SHELL_TEST", nil)
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, s, "ok")
+		words, err := ShellSplitExpand("x ${XSTRING_SHELL_TEST}", nil)
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, words, []string{"x", "ok"})
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	for _, tc := range []struct {
+		give string
+		want string
+	}{
+		{"", "''"},
+		{"file.txt", "file.txt"},
+		{"-a=b,c:d@e%f+g/h_i", "-a=b,c:d@e%f+g/h_i"},
+		{"my file", "'my file'"},
+		{"it's $HOME", `'it'"'"'s $HOME'`},
+		{"a\nb", "'a\nb'"},
+		{"*", "'*'"},
+		{"中文", "'中文'"},
+	} {
+		xtesting.Equal(t, ShellQuote(tc.give), tc.want)
+		words, err := ShellSplit(tc.want)
+		xtesting.Nil(t, err)
+		xtesting.Equal(t, words, []string{tc.give})
+	}
+
+	for _, tc := range []struct {
+		give []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"echo"}, "echo"},
+		{[]string{"echo", "hello world", ""}, "echo 'hello world' ''"},
+		{[]string{"sh", "-c", `echo "$1" 'x'`}, `sh -c 'echo "$1" '"'"'x'"'"''`},
+	} {
+		s := ShellJoin(tc.give)
+		xtesting.Equal(t, s, tc.want)
+		words, err := ShellSplit(s)
+		xtesting.Nil(t, err)
+		if tc.give == nil {
+			tc.give = []string{}
+		}
+		xtesting.Equal(t, words, tc.give)
+	}
+}
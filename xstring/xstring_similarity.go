@@ -0,0 +1,231 @@
+package xstring
+
+import (
+	"sort"
+)
+
+// Note that all the functions in this file operate on runes rather than bytes, so they work well with Unicode text.
+
+// Levenshtein returns the Levenshtein distance between two strings, that is the minimum number of single-rune insertions,
+// deletions and substitutions required to change one string into the other.
+//
+// Example:
+// 	Levenshtein("kitten", "sitting") // => 3
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra // use the shorter one as column
+	}
+	prev, curr := make([]int, len(rb)+1), make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// DamerauLevenshtein returns the Damerau-Levenshtein distance between two strings, which allows transpositions of two
+// adjacent runes in addition to the operations of Levenshtein. Note that this is the unrestricted version, that is a
+// substring can be edited more than once, so DamerauLevenshtein("CA", "ABC") is 2 rather than 3.
+//
+// Example:
+// 	DamerauLevenshtein("abcd", "acbd") // => 1
+func DamerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+	maxDist := la + lb
+	lastRow := make(map[rune]int) // the last row where each rune appears in a
+
+	// d has an extra row and column, d[i+1][j+1] is the distance between ra[:i] and rb[:j]
+	d := make([][]int, la+2)
+	for i := range d {
+		d[i] = make([]int, lb+2)
+	}
+	d[0][0] = maxDist
+	for i := 0; i <= la; i++ {
+		d[i+1][0] = maxDist
+		d[i+1][1] = i
+	}
+	for j := 0; j <= lb; j++ {
+		d[0][j+1] = maxDist
+		d[1][j+1] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		lastMatchCol := 0
+		for j := 1; j <= lb; j++ {
+			i1 := lastRow[rb[j-1]]
+			j1 := lastMatchCol
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+				lastMatchCol = j
+			}
+			d[i+1][j+1] = minInt(
+				d[i][j]+cost, // substitution
+				d[i+1][j]+1,  // insertion
+				d[i][j+1]+1,  // deletion
+				d[i1][j1]+(i-i1-1)+1+(j-j1-1), // transposition
+			)
+		}
+		lastRow[ra[i-1]] = i
+	}
+	return d[la+1][lb+1]
+}
+
+// Jaro returns the Jaro similarity between two strings, which is in [0, 1], and 1 means the two strings are equal.
+func Jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := maxInt(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA, matchedB := make([]bool, len(ra)), make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo, hi := maxInt(0, i-window), minInt(len(rb)-1, i+window)
+		for j := lo; j <= hi; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity between two strings, which is in [0, 1], and 1 means the two strings
+// are equal. This gives more favorable ratings to strings that match from the beginning, using the common prefix up to 4
+// runes and the scaling factor 0.1.
+//
+// Example:
+// 	JaroWinkler("MARTHA", "MARHTA") // => 0.9611...
+func JaroWinkler(a, b string) float64 {
+	jaro := Jaro(a, b)
+	ra, rb := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < 4 && prefix < len(ra) && prefix < len(rb) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// LCSLength returns the length of the longest common subsequence of two strings, counted in runes.
+//
+// Example:
+// 	LCSLength("ABCBDAB", "BDCABA") // => 4
+func LCSLength(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev, curr := make([]int, len(rb)+1), make([]int, len(rb)+1)
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			if ra[i-1] == rb[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else {
+				curr[j] = maxInt(prev[j], curr[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// LCSDistance returns the longest common subsequence distance between two strings, that is the minimum number of rune
+// insertions and deletions required to change one string into the other.
+func LCSDistance(a, b string) int {
+	return len([]rune(a)) + len([]rune(b)) - 2*LCSLength(a, b)
+}
+
+// ClosestMatches returns at most n candidates which are closest to input, ranked by the Levenshtein distance in ascending
+// order, and the Jaro-Winkler similarity in descending order for the same distance, and the given order for the same
+// distance and similarity. Note that all the candidates will be ranked and returned if n is not positive, and the result
+// is supposed to be filtered by caller if needed.
+//
+// Example:
+// 	ClosestMatches("stauts", []string{"start", "status", "stop", "stats"}, 2) // => [stats status]
+func ClosestMatches(input string, candidates []string, n int) []string {
+	type scored struct {
+		candidate  string
+		distance   int
+		similarity float64
+	}
+	scores := make([]scored, 0, len(candidates))
+	for _, c := range candidates {
+		scores = append(scores, scored{candidate: c, distance: Levenshtein(input, c), similarity: JaroWinkler(input, c)})
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].distance != scores[j].distance {
+			return scores[i].distance < scores[j].distance
+		}
+		return scores[i].similarity > scores[j].similarity
+	})
+
+	if n <= 0 || n > len(scores) {
+		n = len(scores)
+	}
+	out := make([]string, 0, n)
+	for _, s := range scores[:n] {
+		out = append(out, s.candidate)
+	}
+	return out
+}
+
+// minInt returns the minimum value of given ints.
+func minInt(first int, others ...int) int {
+	m := first
+	for _, o := range others {
+		if o < m {
+			m = o
+		}
+	}
+	return m
+}
+
+// maxInt returns the maximum value of given ints.
+func maxInt(first int, others ...int) int {
+	m := first
+	for _, o := range others {
+		if o > m {
+			m = o
+		}
+	}
+	return m
+}
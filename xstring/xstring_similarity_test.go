@@ -0,0 +1,86 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	for _, tc := range []struct {
+		giveA     string
+		giveB     string
+		wantLev   int
+		wantDL    int
+		wantLCS   int
+		wantLCSDi int
+	}{
+		{"", "", 0, 0, 0, 0},
+		{"abc", "", 3, 3, 0, 3},
+		{"", "abc", 3, 3, 0, 3},
+		{"abc", "abc", 0, 0, 3, 0},
+		{"kitten", "sitting", 3, 3, 4, 5},
+		{"flaw", "lawn", 2, 2, 3, 2},
+		{"abcd", "acbd", 2, 1, 3, 2},
+		{"ca", "abc", 3, 2, 1, 3},
+		{"ab", "ba", 2, 1, 1, 2},
+		{"ABCBDAB", "BDCABA", 5, 4, 4, 5},
+		{"こんにちは", "こんばんは", 2, 2, 3, 4},
+		{"日本語", "語本日", 2, 2, 1, 4},
+		{"café", "cafe", 1, 1, 3, 2},
+		{"😀😃", "😃😀", 2, 1, 1, 2},
+	} {
+		xtesting.Equal(t, Levenshtein(tc.giveA, tc.giveB), tc.wantLev)
+		xtesting.Equal(t, Levenshtein(tc.giveB, tc.giveA), tc.wantLev)
+		xtesting.Equal(t, DamerauLevenshtein(tc.giveA, tc.giveB), tc.wantDL)
+		xtesting.Equal(t, DamerauLevenshtein(tc.giveB, tc.giveA), tc.wantDL)
+		xtesting.Equal(t, LCSLength(tc.giveA, tc.giveB), tc.wantLCS)
+		xtesting.Equal(t, LCSDistance(tc.giveA, tc.giveB), tc.wantLCSDi)
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	for _, tc := range []struct {
+		giveA    string
+		giveB    string
+		wantJaro float64
+		wantJW   float64
+	}{
+		{"", "", 1, 1},
+		{"abc", "", 0, 0},
+		{"abc", "abc", 1, 1},
+		{"abc", "xyz", 0, 0},
+		{"MARTHA", "MARHTA", 0.944444, 0.961111},
+		{"DWAYNE", "DUANE", 0.822222, 0.840000},
+		{"DIXON", "DICKSONX", 0.766667, 0.813333},
+		{"CRATE", "TRACE", 0.733333, 0.733333},
+		{"a", "a", 1, 1},
+		{"a", "b", 0, 0},
+		{"こんにちは", "こんばんは", 0.733333, 0.786667},
+	} {
+		xtesting.InDelta(t, Jaro(tc.giveA, tc.giveB), tc.wantJaro, 1e-6)
+		xtesting.InDelta(t, Jaro(tc.giveB, tc.giveA), tc.wantJaro, 1e-6)
+		xtesting.InDelta(t, JaroWinkler(tc.giveA, tc.giveB), tc.wantJW, 1e-6)
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	commands := []string{"start", "status", "stop", "stats", "restart", "install"}
+	for _, tc := range []struct {
+		give     string
+		giveCand []string
+		giveN    int
+		want     []string
+	}{
+		{"stauts", commands, 2, []string{"stats", "status"}},
+		{"strat", commands, 1, []string{"start"}},
+		{"resart", commands, 1, []string{"restart"}},
+		{"instal", commands, 3, []string{"install", "start", "stats"}},
+		{"stop", commands, 0, []string{"stop", "start", "stats", "status", "restart", "install"}},
+		{"stop", commands, 100, []string{"stop", "start", "stats", "status", "restart", "install"}},
+		{"abc", nil, 3, []string{}},
+		{"", []string{"b", "a", "cc"}, 3, []string{"b", "a", "cc"}},
+		{"设置", []string{"设定", "配置", "设置项"}, 2, []string{"设置项", "设定"}},
+	} {
+		xtesting.Equal(t, ClosestMatches(tc.give, tc.giveCand, tc.giveN), tc.want)
+	}
+}
@@ -0,0 +1,157 @@
+package xstring
+
+import (
+	"strings"
+	"unicode"
+)
+
+// _transliterations represents the builtin transliteration table, which maps lowercase Latin letters with diacritics, Cyrillic
+// letters and Greek letters to ASCII strings.
+var _transliterations = buildTransliterations()
+
+// buildTransliterations builds the builtin transliteration table.
+func buildTransliterations() map[rune]string {
+	table := make(map[rune]string)
+	for to, froms := range map[string]string{
+		// Latin
+		"a": "àáâãäåāăąǎǻ", "ae": "æǽ", "c": "çćĉċč", "d": "ďđð", "e": "èéêëēĕėęě", "g": "ĝğġģ", "h": "ĥħ",
+		"i": "ìíîïĩīĭįıǐ", "ij": "ĳ", "j": "ĵ", "k": "ķĸ", "l": "ĺļľŀł", "n": "ñńņňŉŋ", "o": "òóôõöøōŏőǒǿ",
+		"oe": "œ", "r": "ŕŗř", "s": "śŝşšș", "ss": "ß", "t": "ţťŧț", "th": "þ", "u": "ùúûüũūŭůűųǔǖǘǚǜ",
+		"w": "ŵ", "y": "ýÿŷ", "z": "źżž",
+		// Cyrillic
+		"b": "б", "v": "в", "zh": "ж", "kh": "х", "ts": "ц", "ch": "ч", "sh": "ш", "shch": "щ",
+		"yu": "ю", "ya": "я", "yo": "ё", "ye": "є", "yi": "ї", "f": "ф", "m": "м", "p": "п",
+		// Greek
+		"ps": "ψ", "x": "ξ",
+	} {
+		for _, from := range froms {
+			table[from] = to
+		}
+	}
+	for from, to := range map[rune]string{
+		// Cyrillic
+		'а': "a", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'э': "e", 'з': "z", 'и': "i", 'і': "i", 'й': "y", 'ы': "y",
+		'к': "k", 'л': "l", 'н': "n", 'о': "o", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ъ': "", 'ь': "",
+		// Greek
+		'α': "a", 'ά': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'έ': "e", 'ζ': "z", 'η': "i", 'ή': "i", 'θ': "th",
+		'ι': "i", 'ί': "i", 'ϊ': "i", 'ΐ': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ο': "o", 'ό': "o", 'π': "p",
+		'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'ύ': "y", 'ϋ': "y", 'ΰ': "y", 'φ': "f", 'χ': "ch", 'ω': "o",
+		'ώ': "o",
+	} {
+		table[from] = to
+	}
+	return table
+}
+
+// Transliterate transliterates given string to ASCII using the given table and the builtin table, here the given table takes
+// precedence and can be used to support other scripts such as CJK romanization. Note that the builtin table only contains
+// Latin letters with diacritics, Cyrillic letters and Greek letters, and the runes which cannot be transliterated are kept.
+//
+// Example:
+// 	Transliterate("Crème Brûlée", nil)                          // => Creme Brulee
+// 	Transliterate("Привет, Αθήνα", nil)                         // => Privet, Athina
+// 	Transliterate("中文", map[rune]string{'中': "zhong", '文': "wen"}) // => zhongwen
+func Transliterate(s string, table map[rune]string) string {
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+	for _, r := range s {
+		if to, ok := table[r]; ok {
+			sb.WriteString(to)
+			continue
+		}
+		if r <= unicode.MaxASCII {
+			sb.WriteRune(r)
+			continue
+		}
+		lower := unicode.ToLower(r)
+		to, ok := _transliterations[lower]
+		if !ok {
+			sb.WriteRune(r)
+			continue
+		}
+		if lower != r {
+			to = Capitalize(to) // uppercase letter
+		}
+		sb.WriteString(to)
+	}
+	return sb.String()
+}
+
+// SlugOptions represents the options used in Slugify.
+type SlugOptions struct {
+	// Separator represents the word separator, defaults to "-".
+	Separator string
+
+	// MaxLength represents the max byte length of the slug, the slug will be cut on word boundaries if it exceeds this length,
+	// and a single long word will be cut directly. Defaults to 0, which means no limit.
+	MaxLength int
+
+	// KeepCase represents whether to keep the letter case, defaults to false, which means to use lowercase.
+	KeepCase bool
+
+	// Transliterations represents the extra transliteration table, see Transliterate for details. Note that the transliterated
+	// strings are joined directly, so please add spaces if words need to be separated, such as `'中': "zhong "`.
+	Transliterations map[rune]string
+}
+
+// Slugify converts given string to a URL-safe slug. Here the string will be transliterated to ASCII first (see Transliterate),
+// then the runs of ASCII letters and digits are treated as words, and the other characters are treated as word separator,
+// except apostrophes which are removed directly. Note that the non-ASCII runes which cannot be transliterated are removed.
+//
+// Example:
+// 	Slugify("Hello, World!", nil)                             // => hello-world
+// 	Slugify("Crème Brûlée & Café", nil)                       // => creme-brulee-cafe
+// 	Slugify("Don't stop me now", &SlugOptions{MaxLength: 12}) // => dont-stop-me
+// 	Slugify("Привет мир", &SlugOptions{Separator: "_"})       // => privet_mir
+func Slugify(s string, opts *SlugOptions) string {
+	if opts == nil {
+		opts = &SlugOptions{}
+	}
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	words := make([]string, 0)
+	word := strings.Builder{}
+	flushWord := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range Transliterate(s, opts.Transliterations) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			if !opts.KeepCase && r >= 'A' && r <= 'Z' {
+				r += 'a' - 'A'
+			}
+			word.WriteRune(r)
+		case r == '\'' || r == '’' || r > unicode.MaxASCII:
+			// skip apostrophes and non-ASCII runes
+		default:
+			flushWord()
+		}
+	}
+	flushWord()
+
+	slug := strings.Join(words, sep)
+	if opts.MaxLength <= 0 || len(slug) <= opts.MaxLength {
+		return slug
+	}
+	length := 0
+	for i, w := range words {
+		newLength := length + len(w)
+		if i > 0 {
+			newLength += len(sep)
+		}
+		if newLength > opts.MaxLength {
+			if i == 0 {
+				return w[:opts.MaxLength] // single long word
+			}
+			return strings.Join(words[:i], sep)
+		}
+		length = newLength
+	}
+	return slug
+}
@@ -0,0 +1,58 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	for _, tc := range []struct {
+		give      string
+		giveTable map[rune]string
+		want      string
+	}{
+		{"", nil, ""},
+		{"hello, world!", nil, "hello, world!"},
+		{"Crème Brûlée", nil, "Creme Brulee"},
+		{"Straße Œuvre Ærø Łódź", nil, "Strasse Oeuvre Aero Lodz"},
+		{"Привет, мир! Щука, Ёж, Київ", nil, "Privet, mir! Shchuka, Yozh, Kiyiv"},
+		{"Αθήνα ψυχή", nil, "Athina psychi"},
+		{"中文 text", nil, "中文 text"},
+		{"中文", map[rune]string{'中': "zhong", '文': "wen"}, "zhongwen"},
+		{"café", map[rune]string{'é': "ee"}, "cafee"},
+	} {
+		xtesting.Equal(t, Transliterate(tc.give, tc.giveTable), tc.want)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	for _, tc := range []struct {
+		give     string
+		giveOpts *SlugOptions
+		want     string
+	}{
+		{"", nil, ""},
+		{"!!!", nil, ""},
+		{"Hello, World!", nil, "hello-world"},
+		{"  --Hello__World--  ", nil, "hello-world"},
+		{"Crème Brûlée & Café", nil, "creme-brulee-cafe"},
+		{"Don't stop me now", nil, "dont-stop-me-now"},
+		{"It’s 2021/01/02", nil, "its-2021-01-02"},
+		{"Привет мир", &SlugOptions{Separator: "_"}, "privet_mir"},
+		{"Ελληνικά κείμενα", &SlugOptions{}, "ellinika-keimena"},
+		{"Hello 世界 World", nil, "hello-world"},
+		{"世界", nil, ""},
+		{"世界", &SlugOptions{Transliterations: map[rune]string{'世': "shi ", '界': "jie "}}, "shi-jie"},
+		{"Hello World", &SlugOptions{KeepCase: true}, "Hello-World"},
+		{"Ça Va", &SlugOptions{KeepCase: true, Separator: "."}, "Ca.Va"},
+		{"Don't stop me now", &SlugOptions{MaxLength: 12}, "dont-stop-me"},
+		{"Don't stop me now", &SlugOptions{MaxLength: 11}, "dont-stop"},
+		{"Don't stop me now", &SlugOptions{MaxLength: 16}, "dont-stop-me-now"},
+		{"Don't stop me now", &SlugOptions{MaxLength: 100}, "dont-stop-me-now"},
+		{"Don't stop me now", &SlugOptions{MaxLength: 3}, "don"},
+		{"a b c", &SlugOptions{MaxLength: 4, Separator: "--"}, "a--b"},
+		{"supercalifragilistic", &SlugOptions{MaxLength: 5}, "super"},
+	} {
+		xtesting.Equal(t, Slugify(tc.give, tc.giveOpts), tc.want)
+	}
+}
@@ -174,6 +174,18 @@ func TestSplitToWords(t *testing.T) {
 		{"测试andテスТестOr", []string{}, []string{"测试andテス", "Тест", "Or"}},
 		{"测试 and テス тест or", []string{"and"}, []string{"测试", "テス", "тест", "or"}},
 		{"mix-Mix_Mix.Mix?mix", []string{"-", "_", ".", "?"}, []string{"mix", "Mix", "Mix", "Mix", "mix"}},
+
+		{"HTTPServer", []string{}, []string{"HTTP", "Server"}},
+		{"HTTPServerID", []string{}, []string{"HTTP", "Server", "ID"}},
+		{"HTTPServerID", []string{"="}, []string{"HTTPServerID"}},
+		{"getHTTPResponseCode", []string{}, []string{"get", "HTTP", "Response", "Code"}},
+		{"IPv4Address", []string{}, []string{"IPv4", "Address"}},
+		{"GetURLs", []string{}, []string{"Get", "URLs"}},
+		{"Base64Encode", []string{}, []string{"Base64", "Encode"}},
+		{"HTTP2Server", []string{}, []string{"HTTP2", "Server"}},
+		{"sha256Sum", []string{}, []string{"sha256", "Sum"}},
+		{"ТЕСТТест", []string{}, []string{"ТЕСТ", "Тест"}},
+		{"JSON_Data", []string{}, []string{"JSON", "Data"}},
 	} {
 		t.Run(tc.giveString, func(t *testing.T) {
 			words := SplitToWords(tc.giveString, tc.giveSeps...)
@@ -203,6 +215,10 @@ func TestXXXCase(t *testing.T) {
 		{"test_snake_case", "TestSnakeCase", "testSnakeCase", "test_snake_case", "test-snake-case"},
 		{"test-kebab-case", "TestKebabCase", "testKebabCase", "test_kebab_case", "test-kebab-case"},
 		{"testMixed_Case-Test.Test", "TestMixedCaseTestTest", "testMixedCaseTestTest", "test_mixed_case_test_test", "test-mixed-case-test-test"},
+		{"HTTPServerID", "HTTPServerID", "httpServerID", "http_server_id", "http-server-id"},
+		{"XMLHttpRequest", "XMLHttpRequest", "xmlHttpRequest", "xml_http_request", "xml-http-request"},
+		{"getURLs", "GetURLs", "getURLs", "get_urls", "get-urls"},
+		{"user_id", "UserId", "userId", "user_id", "user-id"},
 	} {
 		t.Run(tc.give, func(t *testing.T) {
 			xtesting.Equal(t, PascalCase(tc.give), tc.wantP)
@@ -213,6 +229,44 @@ func TestXXXCase(t *testing.T) {
 	}
 }
 
+func TestMoreXXXCase(t *testing.T) {
+	for _, tc := range []struct {
+		give       string
+		wantSS     string
+		wantTrain  string
+		wantDot    string
+		wantTitle  string
+		wantPascal string
+		wantCamel  string
+	}{
+		{"", "", "", "", "", "", ""},
+		{"a", "A", "A", "a", "A", "A", "a"},
+		{"hello world", "HELLO_WORLD", "Hello-World", "hello.world", "Hello World", "HelloWorld", "helloWorld"},
+		{"user_id", "USER_ID", "User-ID", "user.id", "User ID", "UserID", "userID"},
+		{"HTTPServerID", "HTTP_SERVER_ID", "HTTP-Server-ID", "http.server.id", "HTTP Server ID", "HTTPServerID", "httpServerID"},
+		{"http_server_url", "HTTP_SERVER_URL", "HTTP-Server-URL", "http.server.url", "HTTP Server URL", "HTTPServerURL", "httpServerURL"},
+		{"json-api-ids", "JSON_API_IDS", "JSON-API-Ids", "json.api.ids", "JSON API Ids", "JSONAPIIds", "jsonAPIIds"},
+		{"IPv4Address", "IPV4_ADDRESS", "IPv4-Address", "ipv4.address", "IPv4 Address", "IPv4Address", "ipv4Address"},
+		{"тест_тест", "ТЕСТ_ТЕСТ", "Тест-Тест", "тест.тест", "Тест Тест", "ТестТест", "тестТест"},
+	} {
+		t.Run(tc.give, func(t *testing.T) {
+			xtesting.Equal(t, ScreamingSnakeCase(tc.give), tc.wantSS)
+			xtesting.Equal(t, GolintCaseConverter.TrainCase(tc.give), tc.wantTrain)
+			xtesting.Equal(t, DotCase(tc.give), tc.wantDot)
+			xtesting.Equal(t, GolintCaseConverter.TitleCase(tc.give), tc.wantTitle)
+			xtesting.Equal(t, GolintCaseConverter.PascalCase(tc.give), tc.wantPascal)
+			xtesting.Equal(t, GolintCaseConverter.CamelCase(tc.give), tc.wantCamel)
+		})
+	}
+
+	c := &CaseConverter{Initialisms: []string{"db", "Id"}}
+	xtesting.Equal(t, c.PascalCase("user_db_id"), "UserDBID")
+	xtesting.Equal(t, c.TrainCase("user_db_id"), "User-DB-ID")
+	xtesting.Equal(t, (&CaseConverter{}).PascalCase("user_db_id"), "UserDbId")
+	xtesting.Equal(t, PascalCase("user_db_id"), "UserDbId")
+	xtesting.Equal(t, TitleCase("user_db_id"), "User Db Id")
+}
+
 // showFn represents need to show shuffle and random result
 var showFn = func() bool { return false }
 
@@ -257,55 +311,6 @@ func TestTimeUUID(t *testing.T) {
 	}
 }
 
-func TestRandXXXString(t *testing.T) {
-	for _, tc := range []struct {
-		giveFn    func(int) string
-		giveCount int
-	}{
-		{RandCapitalLetterString, 0},
-		{RandCapitalLetterString, 5},
-		{RandCapitalLetterString, 20},
-
-		{RandLowercaseLetterString, 0},
-		{RandLowercaseLetterString, 5},
-		{RandLowercaseLetterString, 20},
-
-		{RandLetterString, 0},
-		{RandLetterString, 5},
-		{RandLetterString, 20},
-
-		{RandNumberString, 0},
-		{RandNumberString, 5},
-		{RandNumberString, 20},
-
-		{RandCapitalLetterNumberString, 0},
-		{RandCapitalLetterNumberString, 5},
-		{RandCapitalLetterNumberString, 20},
-
-		{RandLowercaseLetterNumberString, 0},
-		{RandLowercaseLetterNumberString, 5},
-		{RandLowercaseLetterNumberString, 20},
-	} {
-		r := tc.giveFn(tc.giveCount)
-		if tc.giveCount == 0 {
-			xtesting.Equal(t, r, "")
-		} else {
-			xtesting.Equal(t, len(r), tc.giveCount)
-
-			for i := 0; i < 4; i++ {
-				time.Sleep(2 * time.Nanosecond)
-				r1 := tc.giveFn(tc.giveCount)
-				time.Sleep(2 * time.Nanosecond)
-				r2 := tc.giveFn(tc.giveCount)
-				xtesting.NotEqual(t, r1, r2)
-				if showFn() {
-					fmt.Println(r1, r2)
-				}
-			}
-		}
-	}
-}
-
 func TestMaskToken(t *testing.T) {
 	for _, tc := range []struct {
 		giveString  string
@@ -0,0 +1,28 @@
+//go:build go1.20
+// +build go1.20
+
+package xstring
+
+import (
+	"unsafe"
+)
+
+// FastStob fast casts string to []byte in an unsafe ways, note that the returned bytes must not be modified.
+//
+// This function is implemented by unsafe.Slice and unsafe.StringData, which are available since go1.20.
+func FastStob(s string) []byte {
+	if s == "" {
+		return []byte{}
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// FastBtos fast casts []byte to string in an unsafe ways, note that the given bytes must not be modified after casting.
+//
+// This function is implemented by unsafe.String and unsafe.SliceData, which are available since go1.20.
+func FastBtos(bs []byte) string {
+	if len(bs) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(bs), len(bs))
+}
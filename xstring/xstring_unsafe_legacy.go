@@ -0,0 +1,31 @@
+//go:build !go1.20
+// +build !go1.20
+
+package xstring
+
+import (
+	"unsafe"
+)
+
+// FastStob fast casts string to []byte in an unsafe ways, note that the returned bytes must not be modified.
+//
+// This function is implemented by building slice header manually, and is only used before go1.20.
+func FastStob(s string) []byte {
+	if s == "" {
+		return []byte{}
+	}
+	return *(*[]byte)(unsafe.Pointer(&struct {
+		string
+		Cap int
+	}{s, len(s)}))
+}
+
+// FastBtos fast casts []byte to string in an unsafe ways, note that the given bytes must not be modified after casting.
+//
+// This function is implemented by building string header manually, and is only used before go1.20.
+func FastBtos(bs []byte) string {
+	if len(bs) == 0 {
+		return ""
+	}
+	return *(*string)(unsafe.Pointer(&bs))
+}
@@ -0,0 +1,314 @@
+package xstring
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges represents the rune ranges whose East Asian Width is Wide (W) or Fullwidth (F), including most emoji. For
+// more details, please visit https://www.unicode.org/reports/tr11/.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x231A, 0x231B},   // Watch, Hourglass
+	{0x2329, 0x232A},   // Angle brackets
+	{0x23E9, 0x23EC},   // Media control symbols
+	{0x23F0, 0x23F0},   // Alarm clock
+	{0x23F3, 0x23F3},   // Hourglass with flowing sand
+	{0x25FD, 0x25FE},   // Medium small squares
+	{0x2614, 0x2615},   // Umbrella, Hot beverage
+	{0x2648, 0x2653},   // Zodiac symbols
+	{0x267F, 0x267F},   // Wheelchair symbol
+	{0x2693, 0x2693},   // Anchor
+	{0x26A1, 0x26A1},   // High voltage
+	{0x26AA, 0x26AB},   // Medium circles
+	{0x26BD, 0x26BE},   // Soccer ball, Baseball
+	{0x26C4, 0x26C5},   // Snowman, Sun behind cloud
+	{0x26CE, 0x26CE},   // Ophiuchus
+	{0x26D4, 0x26D4},   // No entry
+	{0x26EA, 0x26EA},   // Church
+	{0x26F2, 0x26F3},   // Fountain, Flag in hole
+	{0x26F5, 0x26F5},   // Sailboat
+	{0x26FA, 0x26FA},   // Tent
+	{0x26FD, 0x26FD},   // Fuel pump
+	{0x2705, 0x2705},   // Check mark button
+	{0x270A, 0x270B},   // Raised fist, Raised hand
+	{0x2728, 0x2728},   // Sparkles
+	{0x274C, 0x274C},   // Cross mark
+	{0x274E, 0x274E},   // Cross mark button
+	{0x2753, 0x2755},   // Question marks
+	{0x2757, 0x2757},   // Exclamation mark
+	{0x2795, 0x2797},   // Plus, Minus, Divide
+	{0x27B0, 0x27B0},   // Curly loop
+	{0x27BF, 0x27BF},   // Double curly loop
+	{0x2B1B, 0x2B1C},   // Large squares
+	{0x2B50, 0x2B50},   // Star
+	{0x2B55, 0x2B55},   // Hollow red circle
+	{0x2E80, 0x303E},   // CJK Radicals Supplement ... CJK Symbols and Punctuation
+	{0x3041, 0x33FF},   // Hiragana ... CJK Compatibility
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi Syllables, Yi Radicals
+	{0xA960, 0xA97F},   // Hangul Jamo Extended-A
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0xFE10, 0xFE19},   // Vertical Forms
+	{0xFE30, 0xFE6F},   // CJK Compatibility Forms, Small Form Variants
+	{0xFF00, 0xFF60},   // Fullwidth Forms
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs
+	{0x16FE0, 0x18AFF}, // Tangut ... Khitan
+	{0x1B000, 0x1B2FF}, // Kana Supplement ... Nushu
+	{0x1F004, 0x1F004}, // Mahjong tile red dragon
+	{0x1F0CF, 0x1F0CF}, // Playing card black joker
+	{0x1F18E, 0x1F18E}, // Negative squared AB
+	{0x1F191, 0x1F19A}, // Squared CL ... Squared VS
+	{0x1F200, 0x1F2FF}, // Enclosed Ideographic Supplement
+	{0x1F300, 0x1F64F}, // Miscellaneous Symbols and Pictographs, Emoticons
+	{0x1F680, 0x1F6FF}, // Transport and Map Symbols
+	{0x1F7E0, 0x1F7EB}, // Colored circles and squares
+	{0x1F90C, 0x1F9FF}, // Supplemental Symbols and Pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and Pictographs Extended-A
+	{0x20000, 0x2FFFD}, // CJK Unified Ideographs Extension B ... F
+	{0x30000, 0x3FFFD}, // CJK Unified Ideographs Extension G ...
+}
+
+// RuneWidth returns the display width of given rune in monospace font, that is 0 for control characters, combining marks
+// and format characters (such as zero width joiner), 2 for East Asian Wide and Fullwidth characters (such as CJK and most
+// emoji), and 1 for others. Note that East Asian Ambiguous characters are regarded as 1.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x300:
+		return 1 // fast path for ASCII and Latin
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || (r >= 0x1160 && r <= 0x11FF): // include Hangul Jamo medial vowels and final consonants
+		return 0
+	}
+	lo, hi := 0, len(wideRanges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		switch {
+		case r < wideRanges[mid][0]:
+			hi = mid
+		case r > wideRanges[mid][1]:
+			lo = mid + 1
+		default:
+			return 2
+		}
+	}
+	return 1
+}
+
+// ansiEscapeLength returns the length in bytes of the ANSI escape sequence at the beginning of s, such as the SGR sequence
+// `\x1b[31m` produced by xcolor, and returns 0 if s does not start with an escape sequence.
+func ansiEscapeLength(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+	switch s[1] {
+	case '[': // CSI, ESC [ params intermediates final
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7E {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']': // OSC, ESC ] ... BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default: // two-character sequence
+		return 2
+	}
+}
+
+// DisplayWidth returns the display width of given string in monospace font, see RuneWidth for details. Note that ANSI
+// escape sequences, such as the colors produced by xcolor, are ignored.
+//
+// Example:
+// 	DisplayWidth("abc")               // => 3
+// 	DisplayWidth("こんにちは")           // => 10
+// 	DisplayWidth("\x1b[31mred\x1b[0m") // => 3
+func DisplayWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if l := ansiEscapeLength(s[i:]); l > 0 {
+			i += l
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		width += RuneWidth(r)
+		i += size
+	}
+	return width
+}
+
+// splitWidth splits s into head and tail, where head is the longest prefix whose display width is not larger than width,
+// and the ANSI escape sequences just after head are also included in head.
+func splitWidth(s string, width int) (head, tail string, headWidth int) {
+	i := 0
+	for i < len(s) {
+		if l := ansiEscapeLength(s[i:]); l > 0 {
+			i += l
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := RuneWidth(r)
+		if headWidth+rw > width {
+			break
+		}
+		headWidth += rw
+		i += size
+	}
+	return s[:i], s[i:], headWidth
+}
+
+// padWidth returns the padding string with given width using char, the remaining width which cannot be filled by char will
+// be filled by space.
+func padWidth(char rune, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	cw := RuneWidth(char)
+	if cw <= 0 {
+		return strings.Repeat(" ", width)
+	}
+	return strings.Repeat(string(char), width/cw) + strings.Repeat(" ", width%cw)
+}
+
+// PadLeftWidth returns a string with padding char in the left to make its display width be totalWidth, this is the display
+// width aware version of PadLeft.
+func PadLeftWidth(s string, char rune, totalWidth int) string {
+	return padWidth(char, totalWidth-DisplayWidth(s)) + s
+}
+
+// PadRightWidth returns a string with padding char in the right to make its display width be totalWidth, this is the display
+// width aware version of PadRight.
+func PadRightWidth(s string, char rune, totalWidth int) string {
+	return s + padWidth(char, totalWidth-DisplayWidth(s))
+}
+
+// Center returns a string with padding char in both sides to make its display width be totalWidth, note that the right side
+// will get one more width if the total padding width is odd.
+func Center(s string, char rune, totalWidth int) string {
+	diff := totalWidth - DisplayWidth(s)
+	if diff <= 0 {
+		return s
+	}
+	return padWidth(char, diff/2) + s + padWidth(char, diff-diff/2)
+}
+
+// TruncateWidth truncates given string to make its display width be not larger than width, and the ellipsis will be appended
+// if the string is truncated. Note that the ANSI escape sequences in the truncated part are kept to keep the style closed.
+//
+// Example:
+// 	TruncateWidth("hello world", 8, "...") // => hello...
+// 	TruncateWidth("こんにちは", 7, "…")      // => こんに…
+func TruncateWidth(s string, width int, ellipsis string) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	ellipsisWidth := DisplayWidth(ellipsis)
+	if ellipsisWidth > width {
+		ellipsis, _, ellipsisWidth = splitWidth(ellipsis, width)
+	}
+	head, tail, _ := splitWidth(s, width-ellipsisWidth)
+
+	sb := strings.Builder{}
+	sb.WriteString(head)
+	sb.WriteString(ellipsis)
+	for i := 0; i < len(tail); { // keep the escape sequences in tail
+		if l := ansiEscapeLength(tail[i:]); l > 0 {
+			sb.WriteString(tail[i : i+l])
+			i += l
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(tail[i:])
+		i += size
+	}
+	return sb.String()
+}
+
+// WrapText wraps given text into lines whose display width are not larger than width, see WrapTextIndent for details.
+func WrapText(s string, width int) string {
+	return WrapTextIndent(s, width, "")
+}
+
+// WrapTextIndent wraps given text into lines whose display width are not larger than width, and uses given indent as the
+// hanging indent of each wrapped line. Note that text is wrapped at spaces, and the word which is wider than a line will
+// be broken, such as a CJK sentence without spaces. Existing newlines are kept, and each line is wrapped separately.
+//
+// Example:
+// 	WrapTextIndent("- the quick brown fox jumps over the lazy dog", 16, "  ")
+// 	// =>
+// 	// - the quick
+// 	//   brown fox
+// 	//   jumps over the
+// 	//   lazy dog
+func WrapTextIndent(s string, width int, indent string) string {
+	indentWidth := DisplayWidth(indent)
+	if width <= 0 || indentWidth >= width {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for idx, line := range lines {
+		lines[idx] = wrapLine(line, width, indent, indentWidth)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine is the implementation for WrapTextIndent, which wraps a single line.
+func wrapLine(line string, width int, indent string, indentWidth int) string {
+	if DisplayWidth(line) <= width {
+		return line
+	}
+	lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	words := strings.Fields(line)
+
+	sb := strings.Builder{}
+	sb.WriteString(lead)
+	current := DisplayWidth(lead)
+	lineStart := true // no word in current line yet
+	newLine := func() {
+		sb.WriteString("\n")
+		sb.WriteString(indent)
+		current = indentWidth
+		lineStart = true
+	}
+
+	for _, word := range words {
+		ww := DisplayWidth(word)
+		if !lineStart && current+1+ww > width {
+			newLine()
+		}
+		if !lineStart {
+			sb.WriteString(" ")
+			current++
+		}
+		for current+ww > width { // break the long word
+			head, tail, hw := splitWidth(word, width-current)
+			if hw == 0 && lineStart && current == indentWidth {
+				// the line cannot contain even a single rune, write the rune directly to avoid infinite loop
+				_, size := utf8.DecodeRuneInString(tail)
+				head, tail, hw = tail[:size], tail[size:], RuneWidth([]rune(tail[:size])[0])
+			}
+			sb.WriteString(head)
+			word, ww = tail, ww-hw
+			if word == "" {
+				current += hw
+				break
+			}
+			newLine()
+		}
+		sb.WriteString(word)
+		current += ww
+		lineStart = false
+	}
+	return sb.String()
+}
@@ -0,0 +1,154 @@
+package xstring
+
+import (
+	"github.com/Aoi-hosizora/ahlib/xtesting"
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	for _, tc := range []struct {
+		give rune
+		want int
+	}{
+		{'a', 1},
+		{' ', 1},
+		{'\t', 0},
+		{'\x1b', 0},
+		{'é', 1},
+		{'́', 0}, // combining acute accent
+		{'‍', 0}, // zero width joiner
+		{'　', 2},
+		{'あ', 2},
+		{'ア', 2},
+		{'ｱ', 1}, // halfwidth katakana
+		{'中', 2},
+		{'한', 2},
+		{'Ａ', 2},
+		{'😀', 2},
+		{'🚀', 2},
+		{'⭐', 2},
+		{'α', 1},
+		{'Я', 1},
+		{'\U00020000', 2},
+	} {
+		xtesting.Equal(t, RuneWidth(tc.give), tc.want)
+	}
+
+	for _, tc := range []struct {
+		give string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"こんにちは", 10},
+		{"Go言語", 6},
+		{"é", 1},
+		{"👨‍👩‍👧", 6},
+		{"\x1b[31mred\x1b[0m", 3},
+		{"\x1b[1;38;5;202m中文\x1b[0m", 4},
+		{"\x1b]0;title\atext", 4},
+		{"\x1b]8;;http://example.com\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"\x1b[", 0},
+	} {
+		xtesting.Equal(t, DisplayWidth(tc.give), tc.want)
+	}
+}
+
+func TestPadWidth(t *testing.T) {
+	for _, tc := range []struct {
+		giveFn    func(string, rune, int) string
+		give      string
+		giveChar  rune
+		giveWidth int
+		want      string
+	}{
+		{PadLeftWidth, "abc", ' ', 5, "  abc"},
+		{PadLeftWidth, "中文", ' ', 6, "  中文"},
+		{PadLeftWidth, "中文", ' ', 3, "中文"},
+		{PadLeftWidth, "ab", '　', 7, "　　 ab"},
+		{PadLeftWidth, "ab", '́', 4, "  ab"},
+		{PadRightWidth, "abc", '.', 5, "abc.."},
+		{PadRightWidth, "中文", '-', 6, "中文--"},
+		{PadRightWidth, "\x1b[31m中\x1b[0m", ' ', 4, "\x1b[31m中\x1b[0m  "},
+		{PadRightWidth, "abc", ' ', 0, "abc"},
+		{Center, "ab", '*', 6, "**ab**"},
+		{Center, "ab", '*', 5, "*ab**"},
+		{Center, "中", ' ', 5, " 中  "},
+		{Center, "abc", ' ', 2, "abc"},
+	} {
+		xtesting.Equal(t, tc.giveFn(tc.give, tc.giveChar, tc.giveWidth), tc.want)
+	}
+
+	// aligned columns
+	rows := []string{"name", "名前", "ﾅﾏｴ", "이름😀"}
+	for _, row := range rows {
+		xtesting.Equal(t, DisplayWidth(PadRightWidth(row, ' ', 10)), 10)
+		xtesting.Equal(t, DisplayWidth(PadLeftWidth(row, ' ', 10)), 10)
+		xtesting.Equal(t, DisplayWidth(Center(row, ' ', 10)), 10)
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	for _, tc := range []struct {
+		give         string
+		giveWidth    int
+		giveEllipsis string
+		want         string
+	}{
+		{"", 0, "...", ""},
+		{"hello", 5, "...", "hello"},
+		{"hello world", 8, "...", "hello..."},
+		{"hello world", 8, "", "hello wo"},
+		{"hello world", 2, "...", ".."},
+		{"hello world", 0, "...", ""},
+		{"こんにちは", 7, "…", "こんに…"},
+		{"こんにちは", 6, "…", "こん…"},
+		{"こんにちは", 6, "", "こんに"},
+		{"ab中文", 3, "", "ab"},
+		{"\x1b[31mhello world\x1b[0m", 6, "…", "\x1b[31mhello…\x1b[0m"},
+		{"\x1b[31mred\x1b[0m\x1b[32mgreen\x1b[0m", 4, "", "\x1b[31mred\x1b[0m\x1b[32mg\x1b[0m"},
+	} {
+		xtesting.Equal(t, TruncateWidth(tc.give, tc.giveWidth, tc.giveEllipsis), tc.want)
+		xtesting.True(t, DisplayWidth(TruncateWidth(tc.give, tc.giveWidth, tc.giveEllipsis)) <= tc.giveWidth)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	for _, tc := range []struct {
+		give       string
+		giveWidth  int
+		giveIndent string
+		want       string
+	}{
+		{"", 10, "", ""},
+		{"short", 10, "", "short"},
+		{"the quick brown fox", 0, "", "the quick brown fox"},
+		{"the quick brown fox", 10, "", "the quick\nbrown fox"},
+		{"the quick brown fox", 9, "", "the quick\nbrown fox"},
+		{"the quick brown fox", 8, "", "the\nquick\nbrown\nfox"},
+		{"the   quick  brown fox", 10, "", "the quick\nbrown fox"},
+		{"  indented text here", 10, "", "  indented\ntext here"},
+		{"- the quick brown fox jumps over the lazy dog", 16, "  ", "- the quick\n  brown fox\n  jumps over the\n  lazy dog"},
+		{"abcdefghijklmnop", 5, "", "abcde\nfghij\nklmno\np"},
+		{"abcdefghij", 5, "  ", "abcde\n  fgh\n  ij"},
+		{"ab abcdefgh", 5, "", "ab\nabcde\nfgh"},
+		{"吾輩は猫である。名前はまだ無い。", 10, "", "吾輩は猫で\nある。名前\nはまだ無い\n。"},
+		{"日本語 テキスト", 7, "", "日本語\nテキス\nト"},
+		{"line one\nline two is long", 8, "", "line one\nline two\nis long"},
+		{"\x1b[31mred\x1b[0m text here", 8, "", "\x1b[31mred\x1b[0m text\nhere"},
+		{"abc", 3, "   ", "abc"},
+		{"abcd", 3, "   ", "abcd"},
+		{"中中", 1, "", "中\n中"},
+	} {
+		xtesting.Equal(t, WrapTextIndent(tc.give, tc.giveWidth, tc.giveIndent), tc.want)
+		if tc.giveIndent == "" {
+			xtesting.Equal(t, WrapText(tc.give, tc.giveWidth), tc.want)
+		}
+	}
+
+	long := strings.Repeat("lorem ipsum dolor sit amet ", 20)
+	for _, line := range strings.Split(WrapTextIndent(long, 30, "    "), "\n") {
+		xtesting.True(t, DisplayWidth(line) <= 30)
+	}
+}